@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Bocmah/phpdocker-gen/pkg/render"
+)
+
+func cleanOutput(conf *Config) {
+	configPath := resolveConfigPath(conf.file)
+
+	checkFileWithConfigurationExists(configPath)
+
+	serviceConf := loadConfig(configPath)
+
+	if !conf.force {
+		checkNoModifiedFiles(serviceConf, "remove")
+	}
+
+	removed, cleanErr := render.Clean(serviceConf)
+
+	for _, path := range removed {
+		fmt.Println("Removed", path)
+	}
+
+	checkErr(cleanErr)
+
+	if len(removed) == 0 {
+		fmt.Println("Nothing to clean")
+	}
+}
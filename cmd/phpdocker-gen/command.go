@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	generateDescription = "Generates docker configuration from the file with services configuration."
+	validateDescription = "Validates the file with services configuration without generating anything."
+	initDescription     = "Creates a starter file with services configuration."
+	diffDescription     = "Shows difference between generated docker configuration and files on disk. Exits with code 3 if they differ."
+	cleanDescription    = "Removes docker configuration which was generated from the file with services configuration."
+	importDescription   = "Creates a file with services configuration from an existing docker-compose setup."
+)
+
+// command is a subcommand of the tool
+type command struct {
+	name        string
+	description string
+	run         func(progname string, args []string)
+}
+
+var commands = []*command{
+	{name: "generate", description: generateDescription, run: runGenerate},
+	{name: "validate", description: validateDescription, run: runValidate},
+	{name: "init", description: initDescription, run: runInit},
+	{name: "diff", description: diffDescription, run: runDiff},
+	{name: "clean", description: cleanDescription, run: runClean},
+	{name: "import", description: importDescription, run: runImport},
+}
+
+func findCommand(name string) *command {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd
+		}
+	}
+
+	return nil
+}
+
+// splitCommand extracts command name from the arguments. Flags without a command are passed to generate command, so
+// the tool can still be invoked as "phpdocker-gen -file <path>"
+func splitCommand(args []string) (name string, rest []string) {
+	if len(args) == 0 {
+		return "help", nil
+	}
+
+	switch args[0] {
+	case "-h", "-help", "--help":
+		return "help", args[1:]
+	}
+
+	if strings.HasPrefix(args[0], "-") {
+		return "generate", args
+	}
+
+	return args[0], args[1:]
+}
+
+func usage(progname string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Usage of %s:\n\n  %s <command> [flags]\n\nCommands:\n", progname, progname)
+
+	for _, cmd := range commands {
+		fmt.Fprintf(&b, "  %-10s%s\n", cmd.name, cmd.description)
+	}
+
+	fmt.Fprintf(&b, "\nRun \"%s help <command>\" for flags of the command.\n", progname)
+
+	return b.String()
+}
+
+func runGenerate(progname string, args []string) {
+	conf, output, err := parseFlags(progname, args)
+	exitOnFlagsErr(output, err)
+	generateDocker(conf)
+}
+
+func runValidate(progname string, args []string) {
+	conf, output, err := parseFileFlags(progname, validateDescription, args)
+	exitOnFlagsErr(output, err)
+	validateConfig(conf)
+}
+
+func runInit(progname string, args []string) {
+	conf, output, err := parseInitFlags(progname, args)
+	exitOnFlagsErr(output, err)
+	initConfig(conf)
+}
+
+func runDiff(progname string, args []string) {
+	conf, output, err := parseDiffFlags(progname, args)
+	exitOnFlagsErr(output, err)
+	diffDocker(conf)
+}
+
+func runClean(progname string, args []string) {
+	conf, output, err := parseCleanFlags(progname, args)
+	exitOnFlagsErr(output, err)
+	cleanOutput(conf)
+}
+
+func runImport(progname string, args []string) {
+	conf, output, err := parseImportFlags(progname, args)
+	exitOnFlagsErr(output, err)
+	importConfig(conf)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSplitCommand(t *testing.T) {
+	var tests = []struct {
+		args     []string
+		wantName string
+		wantRest []string
+	}{
+		{[]string{}, "help", nil},
+		{[]string{"-h"}, "help", []string{}},
+		{[]string{"help", "init"}, "help", []string{"init"}},
+		{[]string{"-file", "conf.yaml"}, "generate", []string{"-file", "conf.yaml"}},
+		{[]string{"generate", "-file", "conf.yaml"}, "generate", []string{"-file", "conf.yaml"}},
+		{[]string{"validate", "-file", "conf.yaml"}, "validate", []string{"-file", "conf.yaml"}},
+		{[]string{"unknown"}, "unknown", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			name, rest := splitCommand(tt.args)
+
+			if name != tt.wantName {
+				t.Errorf("name got %q, want %q", name, tt.wantName)
+			}
+
+			if diff := cmp.Diff(tt.wantRest, rest); diff != "" {
+				t.Errorf("rest mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUsage(t *testing.T) {
+	got := usage("test")
+
+	for _, cmd := range commands {
+		if !strings.Contains(got, cmd.name) || !strings.Contains(got, cmd.description) {
+			t.Errorf("usage does not describe command %s:\n%s", cmd.name, got)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/Bocmah/phpdocker-gen/pkg/assemble"
+	"github.com/Bocmah/phpdocker-gen/pkg/render"
+)
+
+// driftExitCode is returned by diff command when generated files differ from files on disk. It differs from exit codes
+// of errors (1) and invalid flags (2), so CI can tell drift apart from failures
+const driftExitCode = 3
+
+func diffDocker(conf *Config) {
+	configPath := resolveConfigPath(conf.file)
+
+	checkFileWithConfigurationExists(configPath)
+
+	serviceConf := loadConfig(configPath)
+
+	applyTemplatesDir(serviceConf, configPath, conf.templates)
+
+	files, renderErr := render.RenderToMemory(serviceConf, assemble.DockerCompose(serviceConf))
+	checkErr(renderErr)
+
+	diff, diffErr := unifiedDiff(files)
+	checkErr(diffErr)
+
+	if diff == "" {
+		fmt.Println("Docker configuration is up to date")
+		return
+	}
+
+	fmt.Print(diff)
+	os.Exit(driftExitCode)
+}
+
+// unifiedDiff returns unified diff between files on disk and generated files. Empty string means there is no drift
+func unifiedDiff(files []*render.File) (string, error) {
+	var b strings.Builder
+
+	for _, file := range files {
+		existing, exists, readErr := readExisting(file.Path)
+
+		if readErr != nil {
+			return "", readErr
+		}
+
+		if exists && bytes.Equal(existing, file.Content) {
+			continue
+		}
+
+		fromFile := file.Path
+
+		if !exists {
+			fromFile = "/dev/null"
+		}
+
+		diff, diffErr := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(existing),
+			B:        splitLines(file.Content),
+			FromFile: fromFile,
+			ToFile:   file.Path,
+			Context:  3,
+		})
+
+		if diffErr != nil {
+			return "", fmt.Errorf("diff %s: %s", file.Path, diffErr)
+		}
+
+		b.WriteString(diff)
+	}
+
+	return b.String(), nil
+}
+
+// splitLines splits content into lines which keep their line endings. Line ending is added to the last line if it is
+// missing, so the line is not glued to the next one in the diff
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+
+	lines := strings.SplitAfter(string(content), "\n")
+
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+
+	lines[len(lines)-1] += "\n"
+
+	return lines
+}
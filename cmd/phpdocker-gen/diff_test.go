@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+
+	"github.com/Bocmah/phpdocker-gen/pkg/render"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	AppFs = afero.NewMemMapFs()
+
+	if err := afero.WriteFile(AppFs, "/output/php/Dockerfile", []byte("FROM php:7.4-fpm\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %s", err)
+	}
+
+	if err := afero.WriteFile(AppFs, "/output/docker-compose.yml", []byte("version: \"3.7\"\nservices:\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %s", err)
+	}
+
+	files := []*render.File{
+		{Path: "/output/docker-compose.yml", Content: []byte("version: \"3.8\"\nservices:\n")},
+		{Path: "/output/nginx/conf.d/app.conf", Content: []byte("server {\n}\n")},
+		{Path: "/output/php/Dockerfile", Content: []byte("FROM php:7.4-fpm\n")},
+	}
+
+	got, err := unifiedDiff(files)
+
+	if err != nil {
+		t.Fatalf("unifiedDiff() returned error: %s", err)
+	}
+
+	want := `--- /output/docker-compose.yml
++++ /output/docker-compose.yml
+@@ -1,2 +1,2 @@
+-version: "3.7"
++version: "3.8"
+ services:
+--- /dev/null
++++ /output/nginx/conf.d/app.conf
+@@ -0,0 +1,2 @@
++server {
++}
+`
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unifiedDiff() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestUnifiedDiff_NoDrift(t *testing.T) {
+	AppFs = afero.NewMemMapFs()
+
+	if err := afero.WriteFile(AppFs, "/output/php/Dockerfile", []byte("FROM php:7.4-fpm\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %s", err)
+	}
+
+	got, err := unifiedDiff([]*render.File{{Path: "/output/php/Dockerfile", Content: []byte("FROM php:7.4-fpm\n")}})
+
+	if err != nil || got != "" {
+		t.Errorf("unifiedDiff() got %q, %v, want no diff", got, err)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
+	"github.com/Bocmah/phpdocker-gen/pkg/render"
+	"github.com/Bocmah/phpdocker-gen/pkg/service"
+)
+
+func previewDocker(serviceConf *service.FullConfig, compose *dockercompose.Config) {
+	files, renderErr := render.RenderToMemory(serviceConf, compose)
+	checkErr(renderErr)
+
+	report, reportErr := dryRunReport(files)
+	checkErr(reportErr)
+
+	fmt.Print(report)
+}
+
+// dryRunReport describes what would happen to each of the files if they were written to AppFs. Contents are shown
+// for files which would be created or overwritten
+func dryRunReport(files []*render.File) (string, error) {
+	var b strings.Builder
+	var created, overwritten, unchanged int
+
+	for _, file := range files {
+		existing, exists, readErr := readExisting(file.Path)
+
+		switch {
+		case readErr != nil:
+			return "", readErr
+		case !exists:
+			created++
+			fmt.Fprintf(&b, "Would create %s:\n\n%s\n", file.Path, indent(file.Content))
+		case bytes.Equal(existing, file.Content):
+			unchanged++
+			fmt.Fprintf(&b, "Unchanged %s\n\n", file.Path)
+		default:
+			overwritten++
+			fmt.Fprintf(&b, "Would overwrite %s:\n\n%s\n", file.Path, indent(file.Content))
+		}
+	}
+
+	fmt.Fprintf(&b, "Dry run: %d to create, %d to overwrite, %d unchanged. Nothing was written\n",
+		created, overwritten, unchanged)
+
+	return b.String(), nil
+}
+
+func indent(content []byte) string {
+	var b strings.Builder
+
+	for _, line := range strings.SplitAfter(strings.TrimSuffix(string(content), "\n"), "\n") {
+		if line != "\n" {
+			b.WriteString("    ")
+		}
+
+		b.WriteString(line)
+	}
+
+	b.WriteString("\n")
+
+	return b.String()
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+
+	"github.com/Bocmah/phpdocker-gen/pkg/render"
+)
+
+func TestDryRunReport(t *testing.T) {
+	AppFs = afero.NewMemMapFs()
+
+	if err := afero.WriteFile(AppFs, "/output/php/Dockerfile", []byte("FROM php:7.4-fpm\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %s", err)
+	}
+
+	if err := afero.WriteFile(AppFs, "/output/docker-compose.yml", []byte("version: \"3.7\"\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %s", err)
+	}
+
+	files := []*render.File{
+		{Path: "/output/docker-compose.yml", Content: []byte("version: \"3.8\"\n\nservices:\n")},
+		{Path: "/output/nginx/conf.d/app.conf", Content: []byte("server {\n}\n")},
+		{Path: "/output/php/Dockerfile", Content: []byte("FROM php:7.4-fpm\n")},
+	}
+
+	got, err := dryRunReport(files)
+
+	if err != nil {
+		t.Fatalf("dryRunReport() returned error: %s", err)
+	}
+
+	want := `Would overwrite /output/docker-compose.yml:
+
+    version: "3.8"
+
+    services:
+
+Would create /output/nginx/conf.d/app.conf:
+
+    server {
+    }
+
+Unchanged /output/php/Dockerfile
+
+Dry run: 1 to create, 1 to overwrite, 1 unchanged. Nothing was written
+`
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("dryRunReport() mismatch (-want +got):\n%s", diff)
+	}
+
+	if exists, _ := afero.Exists(AppFs, "/output/nginx/conf.d/app.conf"); exists {
+		t.Errorf("dry run created a file")
+	}
+}
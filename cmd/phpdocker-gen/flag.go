@@ -3,21 +3,78 @@ package main
 import (
 	"bytes"
 	"flag"
+	"fmt"
 )
 
-// Config represents command line parameters
+const templatesUsage = "Directory with templates which override the embedded ones (takes precedence over templatesDir)"
+
+// Config represents command line parameters of commands which operate on a file with services configuration
 type Config struct {
-	file string
-	args []string
+	file       string
+	templates  string
+	dryRun     bool
+	force      bool
+	checkPorts bool
+	args       []string
 }
 
 func parseFlags(progname string, args []string) (config *Config, output string, err error) {
-	flags := flag.NewFlagSet(progname, flag.ContinueOnError)
 	var buf bytes.Buffer
-	flags.SetOutput(&buf)
+	flags := newFlagSet(progname, generateDescription, &buf)
+
+	var conf Config
+	flags.StringVar(&conf.file, "file", "", "File with services configuration")
+	flags.StringVar(&conf.templates, "templates", "", templatesUsage)
+	flags.BoolVar(&conf.dryRun, "dry-run", false, "Print files which would be written instead of writing them")
+	flags.BoolVar(&conf.force, "force", false, "Overwrite generated files even if they were modified by hand")
+	flags.BoolVar(&conf.checkPorts, "check-ports", false, "Fail if published host ports are already in use on this machine")
+
+	err = flags.Parse(args)
+	if err != nil {
+		return nil, buf.String(), err
+	}
+	conf.args = flags.Args()
+	return &conf, buf.String(), nil
+}
+
+func parseDiffFlags(progname string, args []string) (config *Config, output string, err error) {
+	var buf bytes.Buffer
+	flags := newFlagSet(progname, diffDescription, &buf)
+
+	var conf Config
+	flags.StringVar(&conf.file, "file", "", "File with services configuration")
+	flags.StringVar(&conf.templates, "templates", "", templatesUsage)
+
+	err = flags.Parse(args)
+	if err != nil {
+		return nil, buf.String(), err
+	}
+	conf.args = flags.Args()
+	return &conf, buf.String(), nil
+}
+
+func parseFileFlags(progname, description string, args []string) (config *Config, output string, err error) {
+	var buf bytes.Buffer
+	flags := newFlagSet(progname, description, &buf)
+
+	var conf Config
+	flags.StringVar(&conf.file, "file", "", "File with services configuration")
+
+	err = flags.Parse(args)
+	if err != nil {
+		return nil, buf.String(), err
+	}
+	conf.args = flags.Args()
+	return &conf, buf.String(), nil
+}
+
+func parseCleanFlags(progname string, args []string) (config *Config, output string, err error) {
+	var buf bytes.Buffer
+	flags := newFlagSet(progname, cleanDescription, &buf)
 
 	var conf Config
 	flags.StringVar(&conf.file, "file", "", "File with services configuration")
+	flags.BoolVar(&conf.force, "force", false, "Remove generated files even if they were modified by hand")
 
 	err = flags.Parse(args)
 	if err != nil {
@@ -26,3 +83,61 @@ func parseFlags(progname string, args []string) (config *Config, output string,
 	conf.args = flags.Args()
 	return &conf, buf.String(), nil
 }
+
+// InitConfig represents command line parameters of init command
+type InitConfig struct {
+	file        string
+	appName     string
+	projectRoot string
+	force       bool
+}
+
+func parseInitFlags(progname string, args []string) (config *InitConfig, output string, err error) {
+	var buf bytes.Buffer
+	flags := newFlagSet(progname, initDescription, &buf)
+
+	var conf InitConfig
+	flags.StringVar(&conf.file, "file", "phpdocker-gen.yaml", "File to which starter configuration will be written")
+	flags.StringVar(&conf.appName, "app-name", "", "Name of the application (name of the project root by default)")
+	flags.StringVar(&conf.projectRoot, "project-root", "", "Path to the project root (current working directory by default)")
+	flags.BoolVar(&conf.force, "force", false, "Overwrite the file if it already exists")
+
+	err = flags.Parse(args)
+	if err != nil {
+		return nil, buf.String(), err
+	}
+	return &conf, buf.String(), nil
+}
+
+// ImportConfig represents command line parameters of import command
+type ImportConfig struct {
+	compose string
+	output  string
+}
+
+func parseImportFlags(progname string, args []string) (config *ImportConfig, output string, err error) {
+	var buf bytes.Buffer
+	flags := newFlagSet(progname, importDescription, &buf)
+
+	var conf ImportConfig
+	flags.StringVar(&conf.compose, "compose", "docker-compose.yml", "Existing docker-compose file")
+	flags.StringVar(&conf.output, "output", "", "File to which resulting services configuration will be written (stdout by default)")
+
+	err = flags.Parse(args)
+	if err != nil {
+		return nil, buf.String(), err
+	}
+	return &conf, buf.String(), nil
+}
+
+// newFlagSet creates flag set which prints command description together with its flags on -h
+func newFlagSet(progname, description string, buf *bytes.Buffer) *flag.FlagSet {
+	flags := flag.NewFlagSet(progname, flag.ContinueOnError)
+	flags.SetOutput(buf)
+	flags.Usage = func() {
+		fmt.Fprintf(buf, "Usage of %s:\n\n%s\n\nFlags:\n", progname, description)
+		flags.PrintDefaults()
+	}
+
+	return flags
+}
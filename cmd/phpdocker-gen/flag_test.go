@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"reflect"
 	"strings"
 	"testing"
@@ -27,6 +28,22 @@ func TestParseFlagsCorrect(t *testing.T) {
 			[]string{"-file", "path/to/file", "another/path/to/file"},
 			Config{file: "path/to/file", args: []string{"another/path/to/file"}},
 		},
+		{
+			[]string{"-file", "path/to/file", "--dry-run"},
+			Config{file: "path/to/file", dryRun: true, args: []string{}},
+		},
+		{
+			[]string{"-file", "path/to/file", "-force"},
+			Config{file: "path/to/file", force: true, args: []string{}},
+		},
+		{
+			[]string{"-file", "path/to/file", "--check-ports"},
+			Config{file: "path/to/file", checkPorts: true, args: []string{}},
+		},
+		{
+			[]string{"-file", "path/to/file", "--templates", "path/to/templates"},
+			Config{file: "path/to/file", templates: "path/to/templates", args: []string{}},
+		},
 	}
 
 	for _, tt := range tests {
@@ -71,3 +88,111 @@ func TestParseFlagsError(t *testing.T) {
 		})
 	}
 }
+
+func TestParseImportFlags(t *testing.T) {
+	var tests = []struct {
+		args []string
+		conf ImportConfig
+	}{
+		{
+			[]string{},
+			ImportConfig{compose: "docker-compose.yml", output: ""},
+		},
+		{
+			[]string{"-compose", "path/to/compose.yml", "-output", "phpdocker-gen.yaml"},
+			ImportConfig{compose: "path/to/compose.yml", output: "phpdocker-gen.yaml"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			conf, output, err := parseImportFlags("test", tt.args)
+			if err != nil {
+				t.Errorf("err got %v, want nil", err)
+			}
+			if output != "" {
+				t.Errorf("output got %q, want empty", output)
+			}
+			if !reflect.DeepEqual(*conf, tt.conf) {
+				t.Errorf("conf got %+v, want %+v", *conf, tt.conf)
+			}
+		})
+	}
+}
+
+func TestParseInitFlags(t *testing.T) {
+	var tests = []struct {
+		args []string
+		conf InitConfig
+	}{
+		{
+			[]string{},
+			InitConfig{file: "phpdocker-gen.yaml"},
+		},
+		{
+			[]string{"-file", "conf.yaml", "-app-name", "app", "-project-root", "/home/user/app", "-force"},
+			InitConfig{file: "conf.yaml", appName: "app", projectRoot: "/home/user/app", force: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			conf, output, err := parseInitFlags("test", tt.args)
+			if err != nil {
+				t.Errorf("err got %v, want nil", err)
+			}
+			if output != "" {
+				t.Errorf("output got %q, want empty", output)
+			}
+			if !reflect.DeepEqual(*conf, tt.conf) {
+				t.Errorf("conf got %+v, want %+v", *conf, tt.conf)
+			}
+		})
+	}
+}
+
+func TestParseDiffFlags(t *testing.T) {
+	conf, output, err := parseDiffFlags("test", []string{"-file", "path/to/file", "-templates", "path/to/templates"})
+
+	if err != nil {
+		t.Fatalf("err got %v, want nil", err)
+	}
+	if output != "" {
+		t.Errorf("output got %q, want empty", output)
+	}
+
+	want := Config{file: "path/to/file", templates: "path/to/templates", args: []string{}}
+
+	if !reflect.DeepEqual(*conf, want) {
+		t.Errorf("conf got %+v, want %+v", *conf, want)
+	}
+}
+
+func TestParseCleanFlags(t *testing.T) {
+	conf, output, err := parseCleanFlags("test", []string{"-file", "path/to/file", "-force"})
+
+	if err != nil {
+		t.Fatalf("err got %v, want nil", err)
+	}
+	if output != "" {
+		t.Errorf("output got %q, want empty", output)
+	}
+
+	want := Config{file: "path/to/file", force: true, args: []string{}}
+
+	if !reflect.DeepEqual(*conf, want) {
+		t.Errorf("conf got %+v, want %+v", *conf, want)
+	}
+}
+
+func TestParseFlagsHelp(t *testing.T) {
+	_, output, err := parseFileFlags("test validate", validateDescription, []string{"-h"})
+
+	if err != flag.ErrHelp {
+		t.Fatalf("err got %v, want %v", err, flag.ErrHelp)
+	}
+
+	if !strings.Contains(output, validateDescription) || !strings.Contains(output, "-file") {
+		t.Errorf("output got %q", output)
+	}
+}
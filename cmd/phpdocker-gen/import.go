@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+
+	"github.com/Bocmah/phpdocker-gen/pkg/disassemble"
+)
+
+func importConfig(conf *ImportConfig) {
+	composePath := resolveConfigPath(conf.compose)
+
+	serviceConf, report, disassembleErr := disassemble.DockerCompose(composePath)
+
+	// The report goes to stderr, so the input file printed to stdout stays valid YAML
+	for _, unmapped := range report {
+		fmt.Fprintln(os.Stderr, "Not imported:", unmapped)
+	}
+
+	checkErr(disassembleErr)
+
+	data, marshalErr := yaml.Marshal(serviceConf)
+	checkErr(marshalErr)
+
+	if conf.output == "" {
+		fmt.Print(string(data))
+		return
+	}
+
+	writeErr := afero.WriteFile(AppFs, resolveConfigPath(conf.output), data, 0644)
+	checkErr(writeErr)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+const starterConfig = `# See https://github.com/Bocmah/phpdocker-gen#the-input-yaml-file for all available options
+appName: %[1]q
+projectRoot: %[2]q
+services:
+  php:
+    version: 7.4
+    extensions:
+      - mbstring
+      - zip
+      - exif
+      - pcntl
+      - gd
+  nginx:
+    httpPort: 80
+    serverName: %[1]q
+  database:
+    system: mysql
+    version: 8.0
+    name: %[1]q
+    username: %[1]q
+    # Change passwords before running containers
+    password: secret
+    rootPassword: secret
+  # nodejs:
+  #   version: latest
+`
+
+func initConfig(conf *InitConfig) {
+	configPath := resolveConfigPath(conf.file)
+
+	if exists, _ := afero.Exists(AppFs, configPath); exists && !conf.force {
+		printAndExit(fmt.Sprintf("File %s already exists. Use -force to overwrite it", configPath))
+	}
+
+	projectRoot := resolveConfigPath(conf.projectRoot)
+	appName := conf.appName
+
+	if appName == "" {
+		appName = filepath.Base(projectRoot)
+	}
+
+	writeErr := afero.WriteFile(AppFs, configPath, scaffold(appName, projectRoot), 0644)
+	checkErr(writeErr)
+
+	fmt.Println("Starter configuration was written to", configPath)
+}
+
+func scaffold(appName, projectRoot string) []byte {
+	return []byte(fmt.Sprintf(starterConfig, appName, projectRoot))
+}
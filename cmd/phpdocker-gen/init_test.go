@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/Bocmah/phpdocker-gen/pkg/service"
+)
+
+func TestInitConfig(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	AppFs = fs
+	service.AppFs = fs
+
+	initConfig(&InitConfig{file: "/home/user/app/phpdocker-gen.yaml", projectRoot: "/home/user/app"})
+
+	conf, loadErr := service.LoadConfigFromFile("/home/user/app/phpdocker-gen.yaml")
+
+	if loadErr != nil {
+		t.Fatalf("starter configuration is invalid: %s", loadErr)
+	}
+
+	if conf.AppName != "app" {
+		t.Errorf("app name got %q, want %q", conf.AppName, "app")
+	}
+
+	if conf.ProjectRoot != "/home/user/app" {
+		t.Errorf("project root got %q, want %q", conf.ProjectRoot, "/home/user/app")
+	}
+}
+
+func TestScaffold_QuotesValues(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	service.AppFs = fs
+
+	const appName = "app: \"quoted\" #1"
+
+	if err := afero.WriteFile(fs, "conf.yaml", scaffold(appName, "/home/user/my app"), 0644); err != nil {
+		t.Fatalf("failed to write config: %s", err)
+	}
+
+	conf, loadErr := service.LoadConfigFromFile("conf.yaml")
+
+	if loadErr != nil {
+		t.Fatalf("starter configuration is invalid: %s", loadErr)
+	}
+
+	if conf.AppName != appName || conf.ProjectRoot != "/home/user/my app" {
+		t.Errorf("values were not preserved: %+v", conf)
+	}
+}
@@ -5,11 +5,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/afero"
 
 	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
-
 	"github.com/Bocmah/phpdocker-gen/pkg/assemble"
 	"github.com/Bocmah/phpdocker-gen/pkg/render"
 	"github.com/Bocmah/phpdocker-gen/pkg/service"
@@ -38,11 +38,54 @@ func generateDocker(conf *Config) {
 
 	serviceConf := loadConfig(configPath)
 
-	renderServices(serviceConf)
+	applyTemplatesDir(serviceConf, configPath, conf.templates)
+
+	compose := assemble.DockerCompose(serviceConf)
+
+	checkHostPorts(compose, conf.checkPorts)
+
+	if conf.dryRun {
+		previewDocker(serviceConf, compose)
+		return
+	}
+
+	if !conf.force {
+		checkNoModifiedFiles(serviceConf, "overwrite")
+	}
+
+	generateErr := render.Generate(serviceConf, compose)
+	checkErr(generateErr)
+}
+
+// checkHostPorts exits if several services publish the same host port. With local set, ports are also probed on
+// this machine, so the ports taken by other programs are reported before containers fail to start
+func checkHostPorts(compose *dockercompose.Config, local bool) {
+	if err := assemble.CheckHostPorts(compose); err != nil {
+		printAndExit(fmt.Sprintf("Docker configuration contains errors:\n\n%v", err))
+	}
+
+	if !local {
+		return
+	}
 
-	composeConf := assemble.DockerCompose(serviceConf)
+	if err := assemble.CheckLocalPorts(compose); err != nil {
+		printAndExit(fmt.Sprintf("Published ports are not available:\n\n%v", err))
+	}
+}
 
-	renderDockerCompose(composeConf, filepath.Join(serviceConf.GetOutputPath(), "docker-compose.yml"))
+// checkNoModifiedFiles exits if generated files were edited by hand, so the action (e.g. overwrite) does not lose
+// the changes
+func checkNoModifiedFiles(conf *service.FullConfig, action string) {
+	modified, modifiedErr := render.ModifiedFiles(conf)
+	checkErr(modifiedErr)
+
+	if len(modified) != 0 {
+		printAndExit(fmt.Sprintf(
+			"Following files were modified after they had been generated:\n\n%s\n\nUse -force to %s them",
+			strings.Join(modified, "\n"),
+			action,
+		))
+	}
 }
 
 func checkFileWithConfigurationExists(filepath string) {
@@ -51,39 +94,51 @@ func checkFileWithConfigurationExists(filepath string) {
 	}
 }
 
-func loadConfig(filepath string) *service.FullConfig {
-	conf, loadConfigErr := service.LoadConfigFromFile(filepath)
+// applyTemplatesDir sets directory with template overrides. Value of the flag takes precedence over the config.
+// Relative path from the config is resolved against the directory of the config file
+func applyTemplatesDir(serviceConf *service.FullConfig, configPath, flagValue string) {
+	if flagValue != "" {
+		serviceConf.TemplatesDir = resolveConfigPath(flagValue)
+	} else if serviceConf.TemplatesDir != "" && !filepath.IsAbs(serviceConf.TemplatesDir) {
+		serviceConf.TemplatesDir = filepath.Join(filepath.Dir(configPath), serviceConf.TemplatesDir)
+	}
 
-	if loadConfigErr != nil {
-		if _, ok := loadConfigErr.(*service.ValidationErrors); ok {
-			printAndExit(fmt.Sprintf("File contains errors:\n\n%v", loadConfigErr))
-		} else {
-			printAndExit(fmt.Sprintf("Encountered error while loading config file:\n\n%v", loadConfigErr))
-		}
+	if serviceConf.TemplatesDir == "" {
+		return
 	}
 
-	return conf
+	if isDir, _ := afero.IsDir(AppFs, serviceConf.TemplatesDir); !isDir {
+		printAndExit(fmt.Sprintf("Directory with templates %s was not found", serviceConf.TemplatesDir))
+	}
 }
 
-func renderServices(conf *service.FullConfig) {
-	rendered, renderErr := render.RenderServices(conf)
+// readExisting reads file at path from AppFs. Missing file is not considered an error
+func readExisting(path string) (content []byte, exists bool, err error) {
+	content, readErr := afero.ReadFile(AppFs, path)
 
-	if renderErr == nil {
-		return
+	if os.IsNotExist(readErr) {
+		return nil, false, nil
 	}
 
-	if rendered != nil {
-		if deleteErr := rendered.DeleteAllCreatedFiles(); deleteErr != nil {
-			fmt.Println(deleteErr)
-		}
+	if readErr != nil {
+		return nil, false, fmt.Errorf("read existing file: %s", readErr)
 	}
 
-	printAndExit(renderErr.Error())
+	return content, true, nil
 }
 
-func renderDockerCompose(conf *dockercompose.Config, outputPath string) {
-	renderErr := render.RenderDockerCompose(conf, outputPath)
-	checkErr(renderErr)
+func loadConfig(filepath string) *service.FullConfig {
+	conf, loadConfigErr := service.LoadConfigFromFile(filepath)
+
+	if loadConfigErr != nil {
+		if _, ok := loadConfigErr.(*service.ValidationErrors); ok {
+			printAndExit(fmt.Sprintf("File contains errors:\n\n%v", loadConfigErr))
+		} else {
+			printAndExit(fmt.Sprintf("Encountered error while loading config file:\n\n%v", loadConfigErr))
+		}
+	}
+
+	return conf
 }
 
 func checkErr(err error) {
@@ -98,8 +153,30 @@ func printAndExit(msg string) {
 }
 
 func main() {
-	flagConf, output, err := parseFlags(os.Args[0], os.Args[1:])
+	name, args := splitCommand(os.Args[1:])
+
+	if name == "help" {
+		if len(args) != 0 {
+			if cmd := findCommand(args[0]); cmd != nil {
+				cmd.run(os.Args[0]+" "+cmd.name, []string{"-h"})
+			}
+		}
+
+		fmt.Print(usage(os.Args[0]))
+		return
+	}
 
+	cmd := findCommand(name)
+
+	if cmd == nil {
+		fmt.Printf("Unknown command %q\n\n%s", name, usage(os.Args[0]))
+		os.Exit(2)
+	}
+
+	cmd.run(os.Args[0]+" "+cmd.name, args)
+}
+
+func exitOnFlagsErr(output string, err error) {
 	if err == flag.ErrHelp {
 		fmt.Println(output)
 		os.Exit(2)
@@ -108,6 +185,4 @@ func main() {
 		fmt.Println("output:\n", output)
 		os.Exit(1)
 	}
-
-	generateDocker(flagConf)
 }
@@ -100,6 +100,18 @@ func createTestDockerComposeConf() map[string]interface{} {
 				},
 				"image":   "test-app",
 				"restart": string(dockercompose.RestartPolicyUnlessStopped),
+				"depends_on": map[interface{}]interface{}{
+					"db": map[interface{}]interface{}{"condition": "service_healthy"},
+				},
+				"healthcheck": map[interface{}]interface{}{
+					"test": []interface{}{
+						"CMD-SHELL",
+						"SCRIPT_NAME=/ping SCRIPT_FILENAME=/ping REQUEST_METHOD=GET cgi-fcgi -bind -connect 127.0.0.1:9000 || exit 1",
+					},
+					"interval": "10s",
+					"timeout":  "5s",
+					"retries":  5,
+				},
 				"networks": []interface{}{
 					networkName,
 				},
@@ -119,14 +131,23 @@ func createTestDockerComposeConf() map[string]interface{} {
 				},
 				"volumes": []interface{}{
 					projectRoot + ":/var/www",
-					filepath.Join(outputPath, "/nginx/conf.d/app.conf") + ":/etc/nginx/conf.d/app.conf",
+					filepath.Join(outputPath, "/nginx/conf.d/app.conf") + ":/etc/nginx/conf.d/app.conf:ro",
 				},
 				"restart": string(dockercompose.RestartPolicyUnlessStopped),
+				"depends_on": map[interface{}]interface{}{
+					"php-fpm": map[interface{}]interface{}{"condition": "service_healthy"},
+				},
 			},
 			"db": map[interface{}]interface{}{
 				"container_name": "db",
 				"image":          "mysql:5.7",
 				"restart":        string(dockercompose.RestartPolicyUnlessStopped),
+				"healthcheck": map[interface{}]interface{}{
+					"test":     []interface{}{"CMD", "mysqladmin", "ping", "-h", "127.0.0.1", "-P", "3306"},
+					"interval": "10s",
+					"timeout":  "5s",
+					"retries":  5,
+				},
 				"ports": []interface{}{
 					"3306:3306",
 				},
@@ -259,3 +280,37 @@ func compareTestFileWithActual(pathToTest, pathToActual string, fs afero.Fs) str
 
 	return ""
 }
+
+func TestApplyTemplatesDir(t *testing.T) {
+	AppFs = afero.NewMemMapFs()
+
+	for _, dir := range []string{"/home/user/conf/templates", "/home/user/custom"} {
+		if err := AppFs.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("failed to create dir: %s", err)
+		}
+	}
+
+	var tests = []struct {
+		name      string
+		configDir string
+		flagValue string
+		want      string
+	}{
+		{"not set", "", "", ""},
+		{"relative to config", "templates", "", "/home/user/conf/templates"},
+		{"absolute in config", "/home/user/custom", "", "/home/user/custom"},
+		{"flag takes precedence", "templates", "/home/user/custom", "/home/user/custom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &service.FullConfig{TemplatesDir: tt.configDir}
+
+			applyTemplatesDir(conf, "/home/user/conf/phpdocker-gen.yaml", tt.flagValue)
+
+			if conf.TemplatesDir != tt.want {
+				t.Errorf("templates dir got %q, want %q", conf.TemplatesDir, tt.want)
+			}
+		})
+	}
+}
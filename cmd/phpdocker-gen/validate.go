@@ -0,0 +1,21 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Bocmah/phpdocker-gen/pkg/assemble"
+)
+
+func validateConfig(conf *Config) {
+	configPath := resolveConfigPath(conf.file)
+
+	checkFileWithConfigurationExists(configPath)
+
+	serviceConf := loadConfig(configPath)
+
+	applyTemplatesDir(serviceConf, configPath, "")
+
+	checkHostPorts(assemble.DockerCompose(serviceConf), false)
+
+	fmt.Println("Configuration is valid")
+}
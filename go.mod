@@ -4,6 +4,8 @@ go 1.15
 
 require (
 	github.com/google/go-cmp v0.5.1
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/spf13/afero v1.4.0
 	gopkg.in/yaml.v2 v2.3.0
+	gopkg.in/yaml.v3 v3.0.1
 )
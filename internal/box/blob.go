@@ -3,7 +3,8 @@ package box
 // Code generated by go generate; DO NOT EDIT.
 
 func init() {
+	box.Add("/mysql/my.cnf.gotmpl", []byte{123, 123, 45, 32, 47, 42, 103, 111, 116, 121, 112, 101, 58, 32, 103, 105, 116, 104, 117, 98, 46, 99, 111, 109, 47, 66, 111, 99, 109, 97, 104, 47, 112, 104, 112, 100, 111, 99, 107, 101, 114, 45, 103, 101, 110, 47, 112, 107, 103, 47, 115, 101, 114, 118, 105, 99, 101, 46, 68, 97, 116, 97, 98, 97, 115, 101, 67, 111, 110, 102, 105, 103, 42, 47, 32, 45, 125, 125, 10, 91, 109, 121, 115, 113, 108, 100, 93, 10, 123, 123, 45, 32, 114, 97, 110, 103, 101, 32, 36, 110, 97, 109, 101, 44, 32, 36, 118, 97, 108, 117, 101, 32, 58, 61, 32, 46, 83, 101, 114, 118, 101, 114, 83, 101, 116, 116, 105, 110, 103, 115, 125, 125, 10, 123, 123, 36, 110, 97, 109, 101, 125, 125, 32, 61, 32, 123, 123, 36, 118, 97, 108, 117, 101, 125, 125, 10, 123, 123, 45, 32, 101, 110, 100, 125, 125, 10})
 	box.Add("/nginx/conf.gotmpl", []byte{123, 123, 45, 32, 47, 42, 103, 111, 116, 121, 112, 101, 58, 32, 103, 105, 116, 104, 117, 98, 46, 99, 111, 109, 47, 66, 111, 99, 109, 97, 104, 47, 112, 104, 112, 100, 111, 99, 107, 101, 114, 45, 103, 101, 110, 47, 112, 107, 103, 47, 115, 101, 114, 118, 105, 99, 101, 46, 70, 117, 108, 108, 67, 111, 110, 102, 105, 103, 42, 47, 32, 45, 125, 125, 10, 115, 101, 114, 118, 101, 114, 32, 123, 10, 32, 32, 32, 32, 108, 105, 115, 116, 101, 110, 32, 123, 123, 46, 83, 101, 114, 118, 105, 99, 101, 115, 46, 78, 103, 105, 110, 120, 46, 72, 84, 84, 80, 80, 111, 114, 116, 125, 125, 59, 10, 32, 32, 32, 32, 105, 110, 100, 101, 120, 32, 105, 110, 100, 101, 120, 46, 112, 104, 112, 32, 105, 110, 100, 101, 120, 46, 104, 116, 109, 108, 59, 10, 10, 32, 32, 32, 32, 101, 114, 114, 111, 114, 95, 108, 111, 103, 32, 32, 47, 118, 97, 114, 47, 108, 111, 103, 47, 110, 103, 105, 110, 120, 47, 101, 114, 114, 111, 114, 46, 108, 111, 103, 59, 10, 32, 32, 32, 32, 97, 99, 99, 101, 115, 115, 95, 108, 111, 103, 32, 47, 118, 97, 114, 47, 108, 111, 103, 47, 110, 103, 105, 110, 120, 47, 97, 99, 99, 101, 115, 115, 46, 108, 111, 103, 59, 10, 10, 32, 32, 32, 32, 114, 111, 111, 116, 32, 47, 118, 97, 114, 47, 119, 119, 119, 47, 112, 117, 98, 108, 105, 99, 59, 10, 10, 32, 32, 32, 32, 115, 101, 114, 118, 101, 114, 95, 110, 97, 109, 101, 32, 123, 123, 46, 83, 101, 114, 118, 105, 99, 101, 115, 46, 78, 103, 105, 110, 120, 46, 83, 101, 114, 118, 101, 114, 78, 97, 109, 101, 125, 125, 46, 116, 101, 115, 116, 59, 10, 10, 32, 32, 32, 32, 108, 111, 99, 97, 116, 105, 111, 110, 32, 126, 32, 92, 46, 112, 104, 112, 36, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 116, 114, 121, 95, 102, 105, 108, 101, 115, 32, 36, 117, 114, 105, 32, 61, 52, 48, 52, 59, 10, 32, 32, 32, 32, 9, 102, 97, 115, 116, 99, 103, 105, 95, 115, 112, 108, 105, 116, 95, 112, 97, 116, 104, 95, 105, 110, 102, 111, 32, 94, 40, 46, 43, 92, 46, 112, 104, 112, 41, 40, 47, 46, 43, 41, 36, 59, 10, 32, 32, 32, 32, 9, 102, 97, 115, 116, 99, 103, 105, 95, 112, 97, 115, 115, 32, 112, 104, 112, 45, 102, 112, 109, 58, 123, 123, 46, 83, 101, 114, 118, 105, 99, 101, 115, 46, 78, 103, 105, 110, 120, 46, 70, 97, 115, 116, 67, 71, 73, 46, 80, 97, 115, 115, 80, 111, 114, 116, 125, 125, 59, 10, 32, 32, 32, 32, 9, 102, 97, 115, 116, 99, 103, 105, 95, 105, 110, 100, 101, 120, 32, 105, 110, 100, 101, 120, 46, 112, 104, 112, 59, 10, 32, 32, 32, 32, 9, 105, 110, 99, 108, 117, 100, 101, 32, 102, 97, 115, 116, 99, 103, 105, 95, 112, 97, 114, 97, 109, 115, 59, 10, 32, 32, 32, 32, 9, 102, 97, 115, 116, 99, 103, 105, 95, 112, 97, 114, 97, 109, 32, 83, 67, 82, 73, 80, 84, 95, 70, 73, 76, 69, 78, 65, 77, 69, 32, 36, 100, 111, 99, 117, 109, 101, 110, 116, 95, 114, 111, 111, 116, 36, 102, 97, 115, 116, 99, 103, 105, 95, 115, 99, 114, 105, 112, 116, 95, 110, 97, 109, 101, 59, 10, 32, 32, 32, 32, 9, 102, 97, 115, 116, 99, 103, 105, 95, 112, 97, 114, 97, 109, 32, 80, 65, 84, 72, 95, 73, 78, 70, 79, 32, 36, 102, 97, 115, 116, 99, 103, 105, 95, 112, 97, 116, 104, 95, 105, 110, 102, 111, 59, 10, 9, 32, 32, 32, 32, 102, 97, 115, 116, 99, 103, 105, 95, 114, 101, 97, 100, 95, 116, 105, 109, 101, 111, 117, 116, 32, 123, 123, 46, 83, 101, 114, 118, 105, 99, 101, 115, 46, 78, 103, 105, 110, 120, 46, 70, 97, 115, 116, 67, 71, 73, 46, 82, 101, 97, 100, 84, 105, 109, 101, 111, 117, 116, 83, 101, 99, 111, 110, 100, 115, 125, 125, 115, 59, 10, 32, 32, 32, 32, 125, 10, 10, 32, 32, 32, 32, 108, 111, 99, 97, 116, 105, 111, 110, 32, 47, 32, 123, 10, 32, 32, 32, 32, 9, 116, 114, 121, 95, 102, 105, 108, 101, 115, 32, 36, 117, 114, 105, 32, 36, 117, 114, 105, 47, 32, 47, 105, 110, 100, 101, 120, 46, 112, 104, 112, 63, 36, 113, 117, 101, 114, 121, 95, 115, 116, 114, 105, 110, 103, 59, 10, 32, 32, 32, 32, 9, 103, 122, 105, 112, 95, 115, 116, 97, 116, 105, 99, 32, 111, 110, 59, 10, 32, 32, 32, 32, 125, 10, 125})
 	box.Add("/nodejs/nodejs.dockerfile.gotmpl", []byte{123, 123, 45, 32, 47, 42, 103, 111, 116, 121, 112, 101, 58, 32, 103, 105, 116, 104, 117, 98, 46, 99, 111, 109, 47, 66, 111, 99, 109, 97, 104, 47, 112, 104, 112, 100, 111, 99, 107, 101, 114, 45, 103, 101, 110, 47, 112, 107, 103, 47, 115, 101, 114, 118, 105, 99, 101, 46, 70, 117, 108, 108, 67, 111, 110, 102, 105, 103, 42, 47, 32, 45, 125, 125, 10, 70, 82, 79, 77, 32, 110, 111, 100, 101, 58, 123, 123, 46, 83, 101, 114, 118, 105, 99, 101, 115, 46, 78, 111, 100, 101, 74, 83, 46, 86, 101, 114, 115, 105, 111, 110, 125, 125})
-	box.Add("/php/php.dockerfile.gotmpl", []byte{123, 123, 45, 32, 47, 42, 103, 111, 116, 121, 112, 101, 58, 32, 103, 105, 116, 104, 117, 98, 46, 99, 111, 109, 47, 66, 111, 99, 109, 97, 104, 47, 112, 104, 112, 100, 111, 99, 107, 101, 114, 45, 103, 101, 110, 47, 112, 107, 103, 47, 115, 101, 114, 118, 105, 99, 101, 46, 70, 117, 108, 108, 67, 111, 110, 102, 105, 103, 42, 47, 32, 45, 125, 125, 10, 70, 82, 79, 77, 32, 112, 104, 112, 58, 123, 123, 46, 83, 101, 114, 118, 105, 99, 101, 115, 46, 80, 72, 80, 46, 86, 101, 114, 115, 105, 111, 110, 125, 125, 45, 102, 112, 109, 10, 10, 35, 32, 67, 111, 112, 121, 32, 99, 111, 109, 112, 111, 115, 101, 114, 46, 108, 111, 99, 107, 32, 97, 110, 100, 32, 99, 111, 109, 112, 111, 115, 101, 114, 46, 106, 115, 111, 110, 10, 67, 79, 80, 89, 32, 99, 111, 109, 112, 111, 115, 101, 114, 46, 108, 111, 99, 107, 32, 99, 111, 109, 112, 111, 115, 101, 114, 46, 106, 115, 111, 110, 32, 47, 118, 97, 114, 47, 119, 119, 119, 47, 10, 10, 35, 32, 83, 101, 116, 32, 119, 111, 114, 107, 105, 110, 103, 32, 100, 105, 114, 101, 99, 116, 111, 114, 121, 10, 87, 79, 82, 75, 68, 73, 82, 32, 47, 118, 97, 114, 47, 119, 119, 119, 10, 10, 35, 32, 73, 110, 115, 116, 97, 108, 108, 32, 100, 101, 112, 101, 110, 100, 101, 110, 99, 105, 101, 115, 10, 82, 85, 78, 32, 97, 112, 116, 45, 103, 101, 116, 32, 117, 112, 100, 97, 116, 101, 32, 38, 38, 32, 97, 112, 116, 45, 103, 101, 116, 32, 105, 110, 115, 116, 97, 108, 108, 32, 45, 121, 32, 92, 10, 32, 32, 32, 32, 98, 117, 105, 108, 100, 45, 101, 115, 115, 101, 110, 116, 105, 97, 108, 32, 92, 10, 32, 32, 32, 32, 108, 105, 98, 112, 113, 45, 100, 101, 118, 32, 92, 10, 32, 32, 32, 32, 108, 105, 98, 112, 110, 103, 45, 100, 101, 118, 32, 92, 10, 32, 32, 32, 32, 108, 105, 98, 106, 112, 101, 103, 54, 50, 45, 116, 117, 114, 98, 111, 45, 100, 101, 118, 32, 92, 10, 32, 32, 32, 32, 108, 105, 98, 102, 114, 101, 101, 116, 121, 112, 101, 54, 45, 100, 101, 118, 32, 92, 10, 32, 32, 32, 32, 108, 111, 99, 97, 108, 101, 115, 32, 92, 10, 32, 32, 32, 32, 122, 105, 112, 32, 92, 10, 32, 32, 32, 32, 106, 112, 101, 103, 111, 112, 116, 105, 109, 32, 111, 112, 116, 105, 112, 110, 103, 32, 112, 110, 103, 113, 117, 97, 110, 116, 32, 103, 105, 102, 115, 105, 99, 108, 101, 32, 92, 10, 32, 32, 32, 32, 118, 105, 109, 32, 92, 10, 32, 32, 32, 32, 117, 110, 122, 105, 112, 32, 92, 10, 32, 32, 32, 32, 103, 105, 116, 32, 92, 10, 32, 32, 32, 32, 99, 117, 114, 108, 10, 10, 35, 32, 67, 108, 101, 97, 114, 32, 99, 97, 99, 104, 101, 10, 82, 85, 78, 32, 97, 112, 116, 45, 103, 101, 116, 32, 99, 108, 101, 97, 110, 32, 38, 38, 32, 114, 109, 32, 45, 114, 102, 32, 47, 118, 97, 114, 47, 108, 105, 98, 47, 97, 112, 116, 47, 108, 105, 115, 116, 115, 47, 42, 10, 35, 32, 73, 110, 115, 116, 97, 108, 108, 32, 97, 110, 100, 32, 101, 110, 97, 98, 108, 101, 32, 101, 120, 116, 101, 110, 115, 105, 111, 110, 115, 123, 123, 119, 105, 116, 104, 32, 46, 83, 101, 114, 118, 105, 99, 101, 115, 46, 80, 72, 80, 46, 69, 120, 116, 101, 110, 115, 105, 111, 110, 115, 125, 125, 10, 82, 85, 78, 32, 100, 111, 99, 107, 101, 114, 45, 112, 104, 112, 45, 101, 120, 116, 45, 105, 110, 115, 116, 97, 108, 108, 32, 92, 10, 32, 32, 32, 32, 123, 123, 32, 114, 97, 110, 103, 101, 32, 36, 105, 110, 100, 101, 120, 44, 32, 36, 101, 108, 101, 109, 101, 110, 116, 32, 58, 61, 32, 46, 125, 125, 123, 123, 105, 102, 32, 36, 105, 110, 100, 101, 120, 125, 125, 32, 92, 10, 32, 32, 32, 32, 123, 123, 101, 110, 100, 125, 125, 123, 123, 36, 101, 108, 101, 109, 101, 110, 116, 125, 125, 123, 123, 101, 110, 100, 125, 125, 10, 123, 123, 101, 110, 100, 125, 125, 10, 35, 32, 73, 110, 115, 116, 97, 108, 108, 32, 99, 111, 109, 112, 111, 115, 101, 114, 10, 82, 85, 78, 32, 99, 117, 114, 108, 32, 45, 115, 83, 32, 104, 116, 116, 112, 115, 58, 47, 47, 103, 101, 116, 99, 111, 109, 112, 111, 115, 101, 114, 46, 111, 114, 103, 47, 105, 110, 115, 116, 97, 108, 108, 101, 114, 32, 124, 32, 112, 104, 112, 32, 45, 45, 32, 45, 45, 105, 110, 115, 116, 97, 108, 108, 45, 100, 105, 114, 61, 47, 117, 115, 114, 47, 108, 111, 99, 97, 108, 47, 98, 105, 110, 32, 45, 45, 102, 105, 108, 101, 110, 97, 109, 101, 61, 99, 111, 109, 112, 111, 115, 101, 114, 10, 10, 35, 32, 65, 100, 100, 32, 117, 115, 101, 114, 10, 82, 85, 78, 32, 103, 114, 111, 117, 112, 97, 100, 100, 32, 45, 103, 32, 49, 48, 48, 48, 32, 119, 119, 119, 10, 82, 85, 78, 32, 117, 115, 101, 114, 97, 100, 100, 32, 45, 117, 32, 49, 48, 48, 48, 32, 45, 109, 115, 32, 47, 98, 105, 110, 47, 98, 97, 115, 104, 32, 45, 103, 32, 119, 119, 119, 32, 119, 119, 119, 10, 10, 35, 32, 67, 111, 112, 121, 32, 101, 120, 105, 115, 116, 105, 110, 103, 32, 97, 112, 112, 108, 105, 99, 97, 116, 105, 111, 110, 32, 100, 105, 114, 101, 99, 116, 111, 114, 121, 32, 99, 111, 110, 116, 101, 110, 116, 115, 10, 67, 79, 80, 89, 32, 46, 32, 47, 118, 97, 114, 47, 119, 119, 119, 10, 10, 35, 32, 67, 111, 112, 121, 32, 101, 120, 105, 115, 116, 105, 110, 103, 32, 97, 112, 112, 108, 105, 99, 97, 116, 105, 111, 110, 32, 100, 105, 114, 101, 99, 116, 111, 114, 121, 32, 112, 101, 114, 109, 105, 115, 115, 105, 111, 110, 115, 10, 67, 79, 80, 89, 32, 45, 45, 99, 104, 111, 119, 110, 61, 119, 119, 119, 58, 119, 119, 119, 32, 46, 32, 47, 118, 97, 114, 47, 119, 119, 119, 10, 10, 35, 32, 67, 104, 97, 110, 103, 101, 32, 99, 117, 114, 114, 101, 110, 116, 32, 117, 115, 101, 114, 32, 116, 111, 32, 119, 119, 119, 10, 85, 83, 69, 82, 32, 119, 119, 119, 10, 10, 35, 32, 83, 116, 97, 114, 116, 32, 112, 104, 112, 45, 102, 112, 109, 32, 115, 101, 114, 118, 101, 114, 10, 69, 88, 80, 79, 83, 69, 32, 123, 123, 46, 83, 101, 114, 118, 105, 99, 101, 115, 46, 78, 103, 105, 110, 120, 46, 70, 97, 115, 116, 67, 71, 73, 46, 80, 97, 115, 115, 80, 111, 114, 116, 125, 125, 10, 67, 77, 68, 32, 91, 34, 112, 104, 112, 45, 102, 112, 109, 34, 93})
+	box.Add("/php/php.dockerfile.gotmpl", []byte{123, 123, 45, 32, 47, 42, 103, 111, 116, 121, 112, 101, 58, 32, 103, 105, 116, 104, 117, 98, 46, 99, 111, 109, 47, 66, 111, 99, 109, 97, 104, 47, 112, 104, 112, 100, 111, 99, 107, 101, 114, 45, 103, 101, 110, 47, 112, 107, 103, 47, 115, 101, 114, 118, 105, 99, 101, 46, 70, 117, 108, 108, 67, 111, 110, 102, 105, 103, 42, 47, 32, 45, 125, 125, 10, 70, 82, 79, 77, 32, 112, 104, 112, 58, 123, 123, 46, 83, 101, 114, 118, 105, 99, 101, 115, 46, 80, 72, 80, 46, 86, 101, 114, 115, 105, 111, 110, 125, 125, 45, 102, 112, 109, 10, 10, 35, 32, 67, 111, 112, 121, 32, 99, 111, 109, 112, 111, 115, 101, 114, 46, 108, 111, 99, 107, 32, 97, 110, 100, 32, 99, 111, 109, 112, 111, 115, 101, 114, 46, 106, 115, 111, 110, 10, 67, 79, 80, 89, 32, 99, 111, 109, 112, 111, 115, 101, 114, 46, 108, 111, 99, 107, 32, 99, 111, 109, 112, 111, 115, 101, 114, 46, 106, 115, 111, 110, 32, 47, 118, 97, 114, 47, 119, 119, 119, 47, 10, 10, 35, 32, 83, 101, 116, 32, 119, 111, 114, 107, 105, 110, 103, 32, 100, 105, 114, 101, 99, 116, 111, 114, 121, 10, 87, 79, 82, 75, 68, 73, 82, 32, 47, 118, 97, 114, 47, 119, 119, 119, 10, 10, 35, 32, 73, 110, 115, 116, 97, 108, 108, 32, 100, 101, 112, 101, 110, 100, 101, 110, 99, 105, 101, 115, 10, 82, 85, 78, 32, 97, 112, 116, 45, 103, 101, 116, 32, 117, 112, 100, 97, 116, 101, 32, 38, 38, 32, 97, 112, 116, 45, 103, 101, 116, 32, 105, 110, 115, 116, 97, 108, 108, 32, 45, 121, 32, 92, 10, 32, 32, 32, 32, 98, 117, 105, 108, 100, 45, 101, 115, 115, 101, 110, 116, 105, 97, 108, 32, 92, 10, 32, 32, 32, 32, 108, 105, 98, 112, 113, 45, 100, 101, 118, 32, 92, 10, 32, 32, 32, 32, 108, 105, 98, 112, 110, 103, 45, 100, 101, 118, 32, 92, 10, 32, 32, 32, 32, 108, 105, 98, 106, 112, 101, 103, 54, 50, 45, 116, 117, 114, 98, 111, 45, 100, 101, 118, 32, 92, 10, 32, 32, 32, 32, 108, 105, 98, 102, 114, 101, 101, 116, 121, 112, 101, 54, 45, 100, 101, 118, 32, 92, 10, 32, 32, 32, 32, 108, 111, 99, 97, 108, 101, 115, 32, 92, 10, 32, 32, 32, 32, 122, 105, 112, 32, 92, 10, 32, 32, 32, 32, 106, 112, 101, 103, 111, 112, 116, 105, 109, 32, 111, 112, 116, 105, 112, 110, 103, 32, 112, 110, 103, 113, 117, 97, 110, 116, 32, 103, 105, 102, 115, 105, 99, 108, 101, 32, 92, 10, 32, 32, 32, 32, 118, 105, 109, 32, 92, 10, 32, 32, 32, 32, 117, 110, 122, 105, 112, 32, 92, 10, 32, 32, 32, 32, 103, 105, 116, 32, 92, 10, 32, 32, 32, 32, 99, 117, 114, 108, 32, 92, 10, 32, 32, 32, 32, 108, 105, 98, 102, 99, 103, 105, 45, 98, 105, 110, 10, 10, 35, 32, 67, 108, 101, 97, 114, 32, 99, 97, 99, 104, 101, 10, 82, 85, 78, 32, 97, 112, 116, 45, 103, 101, 116, 32, 99, 108, 101, 97, 110, 32, 38, 38, 32, 114, 109, 32, 45, 114, 102, 32, 47, 118, 97, 114, 47, 108, 105, 98, 47, 97, 112, 116, 47, 108, 105, 115, 116, 115, 47, 42, 10, 35, 32, 73, 110, 115, 116, 97, 108, 108, 32, 97, 110, 100, 32, 101, 110, 97, 98, 108, 101, 32, 101, 120, 116, 101, 110, 115, 105, 111, 110, 115, 123, 123, 119, 105, 116, 104, 32, 46, 83, 101, 114, 118, 105, 99, 101, 115, 46, 80, 72, 80, 46, 67, 111, 114, 101, 69, 120, 116, 101, 110, 115, 105, 111, 110, 115, 125, 125, 10, 82, 85, 78, 32, 100, 111, 99, 107, 101, 114, 45, 112, 104, 112, 45, 101, 120, 116, 45, 105, 110, 115, 116, 97, 108, 108, 32, 92, 10, 32, 32, 32, 32, 123, 123, 32, 114, 97, 110, 103, 101, 32, 36, 105, 110, 100, 101, 120, 44, 32, 36, 101, 108, 101, 109, 101, 110, 116, 32, 58, 61, 32, 46, 125, 125, 123, 123, 105, 102, 32, 36, 105, 110, 100, 101, 120, 125, 125, 32, 92, 10, 32, 32, 32, 32, 123, 123, 101, 110, 100, 125, 125, 123, 123, 36, 101, 108, 101, 109, 101, 110, 116, 125, 125, 123, 123, 101, 110, 100, 125, 125, 10, 123, 123, 101, 110, 100, 125, 125, 123, 123, 119, 105, 116, 104, 32, 46, 83, 101, 114, 118, 105, 99, 101, 115, 46, 80, 72, 80, 46, 80, 69, 67, 76, 69, 120, 116, 101, 110, 115, 105, 111, 110, 115, 125, 125, 10, 82, 85, 78, 32, 112, 101, 99, 108, 32, 105, 110, 115, 116, 97, 108, 108, 123, 123, 114, 97, 110, 103, 101, 32, 36, 46, 83, 101, 114, 118, 105, 99, 101, 115, 46, 80, 72, 80, 46, 80, 69, 67, 76, 80, 97, 99, 107, 97, 103, 101, 115, 125, 125, 32, 123, 123, 46, 125, 125, 123, 123, 101, 110, 100, 125, 125, 32, 92, 10, 32, 32, 32, 32, 38, 38, 32, 100, 111, 99, 107, 101, 114, 45, 112, 104, 112, 45, 101, 120, 116, 45, 101, 110, 97, 98, 108, 101, 123, 123, 114, 97, 110, 103, 101, 32, 46, 125, 125, 32, 123, 123, 46, 125, 125, 123, 123, 101, 110, 100, 125, 125, 10, 123, 123, 101, 110, 100, 125, 125, 10, 35, 32, 73, 110, 115, 116, 97, 108, 108, 32, 99, 111, 109, 112, 111, 115, 101, 114, 10, 82, 85, 78, 32, 99, 117, 114, 108, 32, 45, 115, 83, 32, 104, 116, 116, 112, 115, 58, 47, 47, 103, 101, 116, 99, 111, 109, 112, 111, 115, 101, 114, 46, 111, 114, 103, 47, 105, 110, 115, 116, 97, 108, 108, 101, 114, 32, 124, 32, 112, 104, 112, 32, 45, 45, 32, 45, 45, 105, 110, 115, 116, 97, 108, 108, 45, 100, 105, 114, 61, 47, 117, 115, 114, 47, 108, 111, 99, 97, 108, 47, 98, 105, 110, 32, 45, 45, 102, 105, 108, 101, 110, 97, 109, 101, 61, 99, 111, 109, 112, 111, 115, 101, 114, 10, 10, 35, 32, 65, 100, 100, 32, 117, 115, 101, 114, 10, 82, 85, 78, 32, 103, 114, 111, 117, 112, 97, 100, 100, 32, 45, 103, 32, 49, 48, 48, 48, 32, 119, 119, 119, 10, 82, 85, 78, 32, 117, 115, 101, 114, 97, 100, 100, 32, 45, 117, 32, 49, 48, 48, 48, 32, 45, 109, 115, 32, 47, 98, 105, 110, 47, 98, 97, 115, 104, 32, 45, 103, 32, 119, 119, 119, 32, 119, 119, 119, 10, 10, 35, 32, 67, 111, 112, 121, 32, 101, 120, 105, 115, 116, 105, 110, 103, 32, 97, 112, 112, 108, 105, 99, 97, 116, 105, 111, 110, 32, 100, 105, 114, 101, 99, 116, 111, 114, 121, 32, 99, 111, 110, 116, 101, 110, 116, 115, 10, 67, 79, 80, 89, 32, 46, 32, 47, 118, 97, 114, 47, 119, 119, 119, 10, 10, 35, 32, 67, 111, 112, 121, 32, 101, 120, 105, 115, 116, 105, 110, 103, 32, 97, 112, 112, 108, 105, 99, 97, 116, 105, 111, 110, 32, 100, 105, 114, 101, 99, 116, 111, 114, 121, 32, 112, 101, 114, 109, 105, 115, 115, 105, 111, 110, 115, 10, 67, 79, 80, 89, 32, 45, 45, 99, 104, 111, 119, 110, 61, 119, 119, 119, 58, 119, 119, 119, 32, 46, 32, 47, 118, 97, 114, 47, 119, 119, 119, 10, 10, 35, 32, 69, 110, 97, 98, 108, 101, 32, 112, 104, 112, 45, 102, 112, 109, 32, 112, 105, 110, 103, 32, 112, 97, 103, 101, 32, 119, 104, 105, 99, 104, 32, 105, 115, 32, 117, 115, 101, 100, 32, 98, 121, 32, 116, 104, 101, 32, 104, 101, 97, 108, 116, 104, 99, 104, 101, 99, 107, 10, 82, 85, 78, 32, 101, 99, 104, 111, 32, 34, 112, 105, 110, 103, 46, 112, 97, 116, 104, 32, 61, 32, 47, 112, 105, 110, 103, 34, 32, 62, 62, 32, 47, 117, 115, 114, 47, 108, 111, 99, 97, 108, 47, 101, 116, 99, 47, 112, 104, 112, 45, 102, 112, 109, 46, 100, 47, 122, 122, 45, 100, 111, 99, 107, 101, 114, 46, 99, 111, 110, 102, 10, 10, 35, 32, 67, 104, 97, 110, 103, 101, 32, 99, 117, 114, 114, 101, 110, 116, 32, 117, 115, 101, 114, 32, 116, 111, 32, 119, 119, 119, 10, 85, 83, 69, 82, 32, 119, 119, 119, 10, 10, 35, 32, 83, 116, 97, 114, 116, 32, 112, 104, 112, 45, 102, 112, 109, 32, 115, 101, 114, 118, 101, 114, 10, 69, 88, 80, 79, 83, 69, 32, 123, 123, 46, 83, 101, 114, 118, 105, 99, 101, 115, 46, 78, 103, 105, 110, 120, 46, 70, 97, 115, 116, 67, 71, 73, 46, 80, 97, 115, 115, 80, 111, 114, 116, 125, 125, 10, 67, 77, 68, 32, 91, 34, 112, 104, 112, 45, 102, 112, 109, 34, 93})
 }
@@ -1,9 +1,6 @@
 package dockercompose
 
-import (
-	"fmt"
-	"strings"
-)
+import "gopkg.in/yaml.v3"
 
 // Build represents 'build' directive in docker-compose file
 type Build struct {
@@ -13,22 +10,48 @@ type Build struct {
 
 // Render formats Build as YAML string
 func (b *Build) Render() string {
+	return directive("build", b.node())
+}
+
+func (b *Build) node() *yaml.Node {
 	if b.Context == "" {
-		return ""
+		return nil
 	}
 
-	var sb strings.Builder
-	sb.WriteString("build:")
-
 	if b.Dockerfile == "" {
-		sb.WriteString(fmt.Sprintf(" %s", b.Context))
+		return stringNode(b.Context)
+	}
+
+	m := mappingNode()
+	appendPair(m, "context", stringNode(b.Context))
+	appendPair(m, "dockerfile", stringNode(b.Dockerfile))
+
+	return m
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. Both short (context only) and long syntax are supported
+func (b *Build) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		b.Context = value.Value
+
+		return nil
+	}
+
+	if err := checkKeys(value, "context", "dockerfile"); err != nil {
+		return err
+	}
+
+	var raw struct {
+		Context    string `yaml:"context"`
+		Dockerfile string `yaml:"dockerfile"`
+	}
 
-		return sb.String()
+	if err := value.Decode(&raw); err != nil {
+		return err
 	}
 
-	sb.WriteString("\n")
-	sb.WriteString(fmt.Sprintf("  context: %s\n", b.Context))
-	sb.WriteString(fmt.Sprintf("  dockerfile: %s", b.Dockerfile))
+	b.Context = raw.Context
+	b.Dockerfile = raw.Dockerfile
 
-	return sb.String()
+	return nil
 }
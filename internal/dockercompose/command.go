@@ -0,0 +1,160 @@
+package dockercompose
+
+import (
+	"errors"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Command overrides the default command of the image. It is rendered in exec form
+type Command []string
+
+// Render formats Command as YAML string
+func (c Command) Render() string {
+	return directive("command", c.node())
+}
+
+func (c Command) node() *yaml.Node {
+	return execFormNode(c)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. Command in shell form is split into words like docker-compose does it
+func (c *Command) UnmarshalYAML(value *yaml.Node) error {
+	args, err := decodeExecForm(value, "command")
+
+	if err != nil {
+		return err
+	}
+
+	*c = args
+
+	return nil
+}
+
+// Entrypoint overrides the default entrypoint of the image. It is rendered in exec form
+type Entrypoint []string
+
+// Render formats Entrypoint as YAML string
+func (e Entrypoint) Render() string {
+	return directive("entrypoint", e.node())
+}
+
+func (e Entrypoint) node() *yaml.Node {
+	return execFormNode(e)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. Entrypoint in shell form is split into words like docker-compose does it
+func (e *Entrypoint) UnmarshalYAML(value *yaml.Node) error {
+	args, err := decodeExecForm(value, "entrypoint")
+
+	if err != nil {
+		return err
+	}
+
+	*e = args
+
+	return nil
+}
+
+func execFormNode(args []string) *yaml.Node {
+	if len(args) == 0 {
+		return nil
+	}
+
+	s := sequenceNode()
+	s.Style = yaml.FlowStyle
+
+	for _, arg := range args {
+		appendItem(s, stringNode(arg))
+	}
+
+	return s
+}
+
+func decodeExecForm(value *yaml.Node, name string) ([]string, error) {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		args, err := splitShellWords(value.Value)
+
+		if err != nil {
+			return nil, nodeError(value, "%s %s", name, err)
+		}
+
+		return args, nil
+	case yaml.SequenceNode:
+		var args []string
+
+		if err := value.Decode(&args); err != nil {
+			return nil, err
+		}
+
+		return args, nil
+	default:
+		return nil, nodeError(value, "%s must be a string or a list", name)
+	}
+}
+
+// splitShellWords splits s into words following POSIX shell quoting rules. Single quotes keep everything literally,
+// backslash escapes any character outside of quotes and only double quote and backslash inside double quotes.
+// Variables and globs are not expanded
+func splitShellWords(s string) ([]string, error) {
+	var (
+		words []string
+		word  strings.Builder
+		quote rune
+	)
+
+	inWord, escaped := false, false
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			if quote == '"' && r != '"' && r != '\\' {
+				word.WriteRune('\\')
+			}
+
+			word.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\\':
+			escaped, inWord = true, true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case strings.ContainsRune(" \t\n\r", r):
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if escaped {
+		return nil, errors.New("ends with an escape character")
+	}
+
+	if quote != 0 {
+		return nil, errors.New("has an unterminated quote")
+	}
+
+	if inWord {
+		words = append(words, word.String())
+	}
+
+	return words, nil
+}
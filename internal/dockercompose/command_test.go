@@ -0,0 +1,168 @@
+package dockercompose_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
+)
+
+func TestCommand_Render(t *testing.T) {
+	tests := map[string]struct {
+		input dockercompose.Command
+		want  string
+	}{
+		"exec form": {
+			input: dockercompose.Command{"redis-server", "--appendonly", "yes", "--requirepass", "p@ss word"},
+			want:  `command: [redis-server, --appendonly, "yes", --requirepass, p@ss word]`,
+		},
+		"empty": {
+			input: nil,
+			want:  "",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.input.Render()); diff != "" {
+				t.Errorf("Command.Render() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCommand_UnmarshalYAML(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    dockercompose.Command
+		wantErr bool
+	}{
+		"shell form": {
+			input: "command: redis-server --appendonly yes",
+			want:  dockercompose.Command{"redis-server", "--appendonly", "yes"},
+		},
+		"shell form with quotes": {
+			input: `command: sh -c "php artisan migrate && php-fpm" --name='my app'`,
+			want:  dockercompose.Command{"sh", "-c", "php artisan migrate && php-fpm", "--name=my app"},
+		},
+		"shell form with escapes": {
+			input: `command: echo a\ b "c \"d\" \$e" \'f`,
+			want:  dockercompose.Command{"echo", "a b", `c "d" \$e`, "'f"},
+		},
+		"shell form with empty argument": {
+			input: `command: redis-server --requirepass ""`,
+			want:  dockercompose.Command{"redis-server", "--requirepass", ""},
+		},
+		"shell form with unterminated quote": {
+			input:   `command: sh -c "php-fpm`,
+			wantErr: true,
+		},
+		"exec form": {
+			input: `command: ["redis-server", "--appendonly", "yes"]`,
+			want:  dockercompose.Command{"redis-server", "--appendonly", "yes"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got struct {
+				Command dockercompose.Command `yaml:"command"`
+			}
+
+			err := yaml.Unmarshal([]byte(tc.input), &got)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got.Command)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("yaml.Unmarshal() returned error: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got.Command); diff != "" {
+				t.Errorf("Command.UnmarshalYAML() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEntrypoint_Render(t *testing.T) {
+	tests := map[string]struct {
+		input dockercompose.Entrypoint
+		want  string
+	}{
+		"exec form": {
+			input: dockercompose.Entrypoint{"docker-php-entrypoint", "php-fpm"},
+			want:  "entrypoint: [docker-php-entrypoint, php-fpm]",
+		},
+		"empty": {
+			input: nil,
+			want:  "",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.input.Render()); diff != "" {
+				t.Errorf("Entrypoint.Render() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEntrypoint_UnmarshalYAML(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    dockercompose.Entrypoint
+		wantErr bool
+	}{
+		"shell form": {
+			input: "entrypoint: /entrypoint.sh --debug",
+			want:  dockercompose.Entrypoint{"/entrypoint.sh", "--debug"},
+		},
+		"shell form with quotes": {
+			input: `entrypoint: /entrypoint.sh --message 'hello world'`,
+			want:  dockercompose.Entrypoint{"/entrypoint.sh", "--message", "hello world"},
+		},
+		"exec form": {
+			input: `entrypoint: ["/entrypoint.sh", "--debug"]`,
+			want:  dockercompose.Entrypoint{"/entrypoint.sh", "--debug"},
+		},
+		"mapping": {
+			input:   "entrypoint:\n  cmd: /entrypoint.sh",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got struct {
+				Entrypoint dockercompose.Entrypoint `yaml:"entrypoint"`
+			}
+
+			err := yaml.Unmarshal([]byte(tc.input), &got)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got.Entrypoint)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("yaml.Unmarshal() returned error: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got.Entrypoint); diff != "" {
+				t.Errorf("Entrypoint.UnmarshalYAML() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
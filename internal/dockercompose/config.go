@@ -2,7 +2,8 @@ package dockercompose
 
 import (
 	"fmt"
-	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Config represents docker-compose file as a struct
@@ -15,32 +16,101 @@ type Config struct {
 
 // Render formats Config as YAML string
 func (c *Config) Render() string {
-	if c.Version == "" || len(c.Services) == 0 {
-		return ""
+	return marshal(c.node())
+}
+
+// MarshalYAML implements yaml.Marshaler, so Config can be passed to the YAML encoder directly
+func (c *Config) MarshalYAML() (interface{}, error) {
+	node := c.node()
+
+	if node == nil {
+		return nil, nil
 	}
 
-	nesting := NestingLevel(1)
+	return node, nil
+}
 
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("version: %s", doubleQuotted(c.Version)))
+func (c *Config) node() *yaml.Node {
+	if c.Version == "" || len(c.Services) == 0 {
+		return nil
+	}
 
-	sb.WriteString("\n")
-	sb.WriteString("services:")
+	services := mappingNode()
 
 	for _, s := range c.Services {
-		sb.WriteString("\n")
-		sb.WriteString(nesting.ApplyTo(s.Render()))
+		appendPair(services, s.Name, s.node())
 	}
 
+	m := mappingNode()
+	appendPair(m, "version", stringNode(c.Version))
+	appendPair(m, "services", services)
+
 	if !c.Networks.IsEmpty() {
-		sb.WriteString("\nnetworks:\n")
-		sb.WriteString(nesting.ApplyTo(c.Networks.Render()))
+		appendPair(m, "networks", c.Networks.node())
 	}
 
 	if !c.Volumes.IsEmpty() {
-		sb.WriteString("\nvolumes:\n")
-		sb.WriteString(nesting.ApplyTo(c.Volumes.Render()))
+		appendPair(m, "volumes", c.Volumes.node())
+	}
+
+	return m
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. Services are kept in the order of declaration and their networks point to
+// the top-level networks with the same name
+func (c *Config) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.MappingNode {
+		return nodeError(value, "compose file must be a mapping")
+	}
+
+	var raw struct {
+		Version  string       `yaml:"version"`
+		Services yaml.Node    `yaml:"services"`
+		Networks Networks     `yaml:"networks"`
+		Volumes  NamedVolumes `yaml:"volumes"`
+	}
+
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	if raw.Services.Kind != 0 && raw.Services.Kind != yaml.MappingNode {
+		return nodeError(&raw.Services, "services must be a mapping")
+	}
+
+	var services []*Service
+
+	for i := 0; i < len(raw.Services.Content); i += 2 {
+		s := &Service{Name: raw.Services.Content[i].Value}
+
+		if err := raw.Services.Content[i+1].Decode(s); err != nil {
+			return err
+		}
+
+		for j, network := range s.Networks {
+			if declared := raw.Networks.find(network.Name); declared != nil {
+				s.Networks[j].Network = declared
+			}
+		}
+
+		services = append(services, s)
+	}
+
+	c.Version = raw.Version
+	c.Services = services
+	c.Networks = raw.Networks
+	c.Volumes = raw.Volumes
+
+	return nil
+}
+
+// Parse reads contents of docker-compose file into Config
+func Parse(data []byte) (*Config, error) {
+	conf := &Config{}
+
+	if err := yaml.Unmarshal(data, conf); err != nil {
+		return nil, fmt.Errorf("parse compose file: %s", err)
 	}
 
-	return sb.String()
+	return conf, nil
 }
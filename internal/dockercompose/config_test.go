@@ -1,16 +1,19 @@
 package dockercompose_test
 
 import (
+	"io/ioutil"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
 
 	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
 )
 
 func TestConfig_Render(t *testing.T) {
 	network := dockercompose.Network{Name: "test-network", Driver: dockercompose.NetworkDriverBridge}
-	networks := dockercompose.ServiceNetworks{&network}
+	networks := dockercompose.ServiceNetworks{{Network: &network}}
 	rootMount := dockercompose.ServiceVolume{Source: "/home/test/app", Target: "/var/www"}
 	namedVol := dockercompose.NamedVolume{Name: "test-data", Driver: dockercompose.VolumeDriverLocal}
 
@@ -129,3 +132,363 @@ volumes:
 		t.Errorf("conf.Render() mismatch (-want +got):\n%s", diff)
 	}
 }
+
+func TestConfig_RenderEscapesValues(t *testing.T) {
+	conf := dockercompose.Config{
+		Version: "3.8",
+		Services: []*dockercompose.Service{
+			{
+				Name:  "db",
+				Image: &dockercompose.Image{Name: "mysql", Tag: "8.0"},
+				Environment: dockercompose.Environment{
+					"MYSQL_ROOT_PASSWORD": `p@ss: #"word"`,
+					"MYSQL_PASSWORD":      "yes",
+					"MYSQL_USER":          "multi\nline",
+				},
+				Volumes: dockercompose.ServiceVolumes{
+					&dockercompose.ServiceVolume{Source: "/home/test/my app", Target: "/var/www"},
+				},
+			},
+		},
+	}
+
+	want := map[string]interface{}{
+		"version": "3.8",
+		"services": map[string]interface{}{
+			"db": map[string]interface{}{
+				"image": "mysql:8.0",
+				"environment": map[string]interface{}{
+					"MYSQL_ROOT_PASSWORD": `p@ss: #"word"`,
+					"MYSQL_PASSWORD":      "yes",
+					"MYSQL_USER":          "multi\nline",
+				},
+				"volumes": []interface{}{"/home/test/my app:/var/www"},
+			},
+		},
+	}
+
+	got := map[string]interface{}{}
+
+	if err := yaml.Unmarshal([]byte(conf.Render()), &got); err != nil {
+		t.Fatalf("failed to unmarshal rendered config: %s", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("conf.Render() round trip mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConfig_MarshalYAML(t *testing.T) {
+	conf := &dockercompose.Config{
+		Version: "3.8",
+		Services: []*dockercompose.Service{
+			{Name: "webserver", Image: &dockercompose.Image{Name: "nginx", Tag: "alpine"}},
+		},
+	}
+
+	got, err := yaml.Marshal(conf)
+
+	if err != nil {
+		t.Fatalf("failed to marshal config: %s", err)
+	}
+
+	want := `version: "3.8"
+services:
+    webserver:
+        image: nginx:alpine
+`
+
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("yaml.Marshal(conf) mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConfig_RenderIsReproducible(t *testing.T) {
+	network := &dockercompose.Network{Name: "app-network", Driver: dockercompose.NetworkDriverBridge}
+	namedVol := &dockercompose.NamedVolume{Name: "app-data", Driver: dockercompose.VolumeDriverLocal}
+
+	conf := dockercompose.Config{
+		Version: "3.8",
+		Services: []*dockercompose.Service{
+			{
+				Name:          "db",
+				Image:         &dockercompose.Image{Name: "mysql", Tag: "8.0"},
+				ContainerName: "db",
+				Restart:       dockercompose.RestartPolicyUnlessStopped,
+				Ports:         dockercompose.Ports{&dockercompose.PortsMapping{Host: 3306, Container: 3306}},
+				Environment: dockercompose.Environment{
+					"MYSQL_USER":          "app",
+					"MYSQL_ROOT_PASSWORD": "secret-root",
+					"MYSQL_PASSWORD":      "secret",
+					"MYSQL_DATABASE":      "app-db",
+				},
+				Networks: dockercompose.ServiceNetworks{{Network: network}},
+				Volumes:  dockercompose.ServiceVolumes{&dockercompose.ServiceVolume{Source: namedVol.Name, Target: "/var/lib/mysql"}},
+			},
+			{
+				Name:          "analytics",
+				Image:         &dockercompose.Image{Name: "postgres", Tag: "12.3"},
+				ContainerName: "analytics",
+				Environment: dockercompose.Environment{
+					"POSTGRES_USER":     "app",
+					"POSTGRES_PASSWORD": "secret",
+					"POSTGRES_DB":       "analytics",
+				},
+				Networks: dockercompose.ServiceNetworks{{Network: network}},
+			},
+		},
+		Networks: dockercompose.Networks{network},
+		Volumes:  dockercompose.NamedVolumes{namedVol},
+	}
+
+	golden, readErr := ioutil.ReadFile("testdata/docker-compose.golden.yml")
+
+	if readErr != nil {
+		t.Fatalf("failed to read golden file: %s", readErr)
+	}
+
+	for i := 0; i < 20; i++ {
+		if diff := cmp.Diff(string(golden), conf.Render()+"\n"); diff != "" {
+			t.Fatalf("conf.Render() differs from golden file on run %d (-want +got):\n%s", i+1, diff)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	data := []byte(`version: "3.8"
+services:
+  app:
+    build: /home/test/app
+    image: registry.local:5000/app
+    container_name: app
+    working_dir: /var/www
+    restart: "no"
+    environment:
+      - APP_ENV=local
+      - APP_DEBUG
+    networks:
+      app-network:
+      proxy:
+        aliases:
+          - app.test
+        ipv4_address: 172.30.0.10
+    volumes:
+      - /home/test/app:/var/www
+      - type: volume
+        source: app-data
+        target: /var/lib/app
+    healthcheck:
+      test: curl -f http://localhost
+      interval: 30s
+      retries: 3
+  webserver:
+    build:
+      context: /home/test/app
+      dockerfile: .docker/nginx/Dockerfile
+    image: nginx:alpine
+    restart: unless-stopped
+    ports:
+      - "80:80"
+      - 443:443
+      - 9000
+      - target: 8080
+        published: 8081
+    environment:
+      NGINX_PORT: 80
+      EMPTY:
+    networks:
+      - app-network
+      - undeclared
+    depends_on:
+      - app
+networks:
+  app-network:
+    driver: bridge
+  default-network:
+  proxy:
+    external: true
+  backend:
+    ipam:
+      config:
+        - subnet: 172.28.0.0/16
+          gateway: 172.28.0.1
+volumes:
+  app-data:
+  custom-data:
+    driver: foo
+`)
+
+	network := &dockercompose.Network{Name: "app-network", Driver: dockercompose.NetworkDriverBridge}
+	proxy := &dockercompose.Network{Name: "proxy", External: true}
+
+	want := &dockercompose.Config{
+		Version: "3.8",
+		Services: []*dockercompose.Service{
+			{
+				Name:          "app",
+				Build:         &dockercompose.Build{Context: "/home/test/app"},
+				Image:         &dockercompose.Image{Name: "registry.local:5000/app"},
+				ContainerName: "app",
+				WorkingDir:    "/var/www",
+				Restart:       dockercompose.RestartPolicyNo,
+				Environment:   dockercompose.Environment{"APP_ENV": "local", "APP_DEBUG": ""},
+				Networks: dockercompose.ServiceNetworks{
+					{Network: network},
+					{Network: proxy, Aliases: []string{"app.test"}, IPv4Address: "172.30.0.10"},
+				},
+				Volumes: dockercompose.ServiceVolumes{
+					{Source: "/home/test/app", Target: "/var/www"},
+					{Type: dockercompose.VolumeTypeVolume, Source: "app-data", Target: "/var/lib/app"},
+				},
+				Healthcheck: &dockercompose.Healthcheck{
+					Test:     []string{"CMD-SHELL", "curl -f http://localhost"},
+					Interval: "30s",
+					Retries:  3,
+				},
+			},
+			{
+				Name:    "webserver",
+				Build:   &dockercompose.Build{Context: "/home/test/app", Dockerfile: ".docker/nginx/Dockerfile"},
+				Image:   &dockercompose.Image{Name: "nginx", Tag: "alpine"},
+				Restart: dockercompose.RestartPolicyUnlessStopped,
+				Ports: dockercompose.Ports{
+					{Host: 80, Container: 80},
+					{Host: 443, Container: 443},
+					{Container: 9000},
+					{Host: 8081, Container: 8080},
+				},
+				Environment: dockercompose.Environment{"NGINX_PORT": "80", "EMPTY": ""},
+				Networks:    dockercompose.ServiceNetworks{{Network: network}, {Network: &dockercompose.Network{Name: "undeclared"}}},
+				DependsOn: dockercompose.Dependencies{
+					{Service: "app", Condition: dockercompose.ConditionServiceStarted},
+				},
+			},
+		},
+		Networks: dockercompose.Networks{
+			network,
+			{Name: "default-network", Driver: dockercompose.NetworkDriverBridge},
+			proxy,
+			{
+				Name:   "backend",
+				Driver: dockercompose.NetworkDriverBridge,
+				IPAM: &dockercompose.IPAM{
+					Config: []*dockercompose.IPAMPool{{Subnet: "172.28.0.0/16", Gateway: "172.28.0.1"}},
+				},
+			},
+		},
+		Volumes: dockercompose.NamedVolumes{
+			{Name: "app-data", Driver: dockercompose.VolumeDriverLocal},
+			{Name: "custom-data", Driver: "foo"},
+		},
+	}
+
+	got, err := dockercompose.Parse(data)
+
+	if err != nil {
+		t.Fatalf("encountered error when parsing correct file: %s", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("Parse() mismatch (-want +got):\n%s", diff)
+	}
+
+	if got.Services[0].Networks[0].Network != got.Networks[0] {
+		t.Errorf("service network does not point to the top-level network")
+	}
+}
+
+func TestParse_RoundTrip(t *testing.T) {
+	golden, readErr := ioutil.ReadFile("testdata/docker-compose.golden.yml")
+
+	if readErr != nil {
+		t.Fatalf("failed to read golden file: %s", readErr)
+	}
+
+	conf, parseErr := dockercompose.Parse(golden)
+
+	if parseErr != nil {
+		t.Fatalf("failed to parse golden file: %s", parseErr)
+	}
+
+	if diff := cmp.Diff(string(golden), conf.Render()+"\n"); diff != "" {
+		t.Errorf("parsed and rendered config mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		wantErr string
+	}{
+		"not a mapping": {
+			input:   "- foo",
+			wantErr: "compose file must be a mapping",
+		},
+		"invalid yaml": {
+			input:   "services: [",
+			wantErr: "parse compose file",
+		},
+		"unknown restart policy": {
+			input:   "services:\n  app:\n    restart: sometimes",
+			wantErr: `line 3: unknown restart policy "sometimes"`,
+		},
+		"port with too many parts": {
+			input:   "services:\n  app:\n    ports:\n      - 127.0.0.1:80:80:80",
+			wantErr: `line 4: unsupported port mapping "127.0.0.1:80:80:80"`,
+		},
+		"port ranges of different sizes": {
+			input:   "services:\n  app:\n    ports:\n      - 8000-8010:8000-8005",
+			wantErr: "port ranges",
+		},
+		"port with unknown protocol": {
+			input:   "services:\n  app:\n    ports:\n      - 80:80/sctp",
+			wantErr: `unsupported port protocol "sctp"`,
+		},
+		"volume with unknown mode": {
+			input:   "services:\n  app:\n    volumes:\n      - ./conf:/etc/conf:rx",
+			wantErr: `unsupported volume mode "rx"`,
+		},
+		"volume with too many parts": {
+			input:   "services:\n  app:\n    volumes:\n      - ./conf:/etc/conf:ro:z",
+			wantErr: `unsupported volume mapping "./conf:/etc/conf:ro:z"`,
+		},
+		"volume with unknown type": {
+			input:   "services:\n  app:\n    volumes:\n      - type: npipe\n        target: /etc/conf",
+			wantErr: `unsupported volume type "npipe"`,
+		},
+		"build with args": {
+			input:   "services:\n  app:\n    build:\n      context: .\n      args:\n        FOO: bar",
+			wantErr: `line 5: unsupported option "args"`,
+		},
+		"named volume with unsupported option": {
+			input:   "volumes:\n  app-data:\n    name: custom-name",
+			wantErr: `unsupported option "name"`,
+		},
+		"network with unsupported option": {
+			input:   "networks:\n  proxy:\n    attachable: true",
+			wantErr: `unsupported option "attachable"`,
+		},
+		"ipam pool with unsupported option": {
+			input:   "networks:\n  proxy:\n    ipam:\n      config:\n        - aux_addresses: {}",
+			wantErr: `unsupported option "aux_addresses"`,
+		},
+		"service network with unsupported option": {
+			input:   "services:\n  app:\n    networks:\n      proxy:\n        priority: 100",
+			wantErr: `unsupported option "priority"`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := dockercompose.Parse([]byte(tc.input))
+
+			if err == nil {
+				t.Fatalf("encountered nil err when parsing incorrect file")
+			}
+
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("incorrect err value: %s, want to find %s", err, tc.wantErr)
+			}
+		})
+	}
+}
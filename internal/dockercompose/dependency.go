@@ -0,0 +1,96 @@
+package dockercompose
+
+import "gopkg.in/yaml.v3"
+
+// DependencyCondition is a state of the dependency which is required for the service to start
+type DependencyCondition string
+
+// All supported dependency conditions
+const (
+	ConditionServiceStarted               DependencyCondition = "service_started"
+	ConditionServiceHealthy               DependencyCondition = "service_healthy"
+	ConditionServiceCompletedSuccessfully DependencyCondition = "service_completed_successfully"
+)
+
+// Dependency is a service which has to be started before the service which depends on it
+type Dependency struct {
+	Service   string
+	Condition DependencyCondition
+}
+
+// Dependencies represents service-level 'depends_on' directive. It is rendered in long syntax
+type Dependencies []*Dependency
+
+// Render formats Dependencies as YAML string
+func (d Dependencies) Render() string {
+	return directive("depends_on", d.node())
+}
+
+func (d Dependencies) node() *yaml.Node {
+	m := mappingNode()
+
+	for _, dep := range d {
+		if dep.Service == "" {
+			continue
+		}
+
+		condition := dep.Condition
+
+		if condition == "" {
+			condition = ConditionServiceStarted
+		}
+
+		depNode := mappingNode()
+		appendPair(depNode, "condition", stringNode(string(condition)))
+
+		appendPair(m, dep.Service, depNode)
+	}
+
+	return emptyToNil(m)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. Both short (list of services) and long syntax are supported
+func (d *Dependencies) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.SequenceNode:
+		var services []string
+
+		if err := value.Decode(&services); err != nil {
+			return err
+		}
+
+		for _, s := range services {
+			*d = append(*d, &Dependency{Service: s, Condition: ConditionServiceStarted})
+		}
+	case yaml.MappingNode:
+		for i := 0; i < len(value.Content); i += 2 {
+			key, val := value.Content[i], value.Content[i+1]
+
+			if err := checkKeys(val, "condition", "restart", "required"); err != nil {
+				return err
+			}
+
+			var raw struct {
+				Condition DependencyCondition `yaml:"condition"`
+			}
+
+			if err := val.Decode(&raw); err != nil {
+				return err
+			}
+
+			switch raw.Condition {
+			case "":
+				raw.Condition = ConditionServiceStarted
+			case ConditionServiceStarted, ConditionServiceHealthy, ConditionServiceCompletedSuccessfully:
+			default:
+				return nodeError(val, "unknown dependency condition %q", raw.Condition)
+			}
+
+			*d = append(*d, &Dependency{Service: key.Value, Condition: raw.Condition})
+		}
+	default:
+		return nodeError(value, "depends_on must be a list or a mapping")
+	}
+
+	return nil
+}
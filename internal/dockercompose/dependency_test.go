@@ -0,0 +1,111 @@
+package dockercompose_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
+)
+
+func TestDependencies_Render(t *testing.T) {
+	tests := map[string]struct {
+		input dockercompose.Dependencies
+		want  string
+	}{
+		"with conditions": {
+			input: dockercompose.Dependencies{
+				{Service: "db", Condition: dockercompose.ConditionServiceHealthy},
+				{Service: "mongodb", Condition: dockercompose.ConditionServiceStarted},
+			},
+			want: `depends_on:
+  db:
+    condition: service_healthy
+  mongodb:
+    condition: service_started`,
+		},
+		"without condition": {
+			input: dockercompose.Dependencies{{Service: "php-fpm"}},
+			want: `depends_on:
+  php-fpm:
+    condition: service_started`,
+		},
+		"empty": {
+			input: nil,
+			want:  "",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.input.Render()); diff != "" {
+				t.Errorf("Dependencies.Render() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDependencies_UnmarshalYAML(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  dockercompose.Dependencies
+	}{
+		"short syntax": {
+			input: "depends_on: [db, redis]",
+			want: dockercompose.Dependencies{
+				{Service: "db", Condition: dockercompose.ConditionServiceStarted},
+				{Service: "redis", Condition: dockercompose.ConditionServiceStarted},
+			},
+		},
+		"long syntax": {
+			input: `depends_on:
+  db:
+    condition: service_healthy
+  migrations:
+    condition: service_completed_successfully
+  redis: {}`,
+			want: dockercompose.Dependencies{
+				{Service: "db", Condition: dockercompose.ConditionServiceHealthy},
+				{Service: "migrations", Condition: dockercompose.ConditionServiceCompletedSuccessfully},
+				{Service: "redis", Condition: dockercompose.ConditionServiceStarted},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got struct {
+				DependsOn dockercompose.Dependencies `yaml:"depends_on"`
+			}
+
+			if err := yaml.Unmarshal([]byte(tc.input), &got); err != nil {
+				t.Fatalf("yaml.Unmarshal() returned error: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got.DependsOn); diff != "" {
+				t.Errorf("Dependencies.UnmarshalYAML() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDependencies_UnmarshalYAMLErrors(t *testing.T) {
+	tests := map[string]string{
+		"unknown condition":  "depends_on:\n  db:\n    condition: service_ready",
+		"unsupported option": "depends_on:\n  db:\n    wait: true",
+		"scalar":             "depends_on: db",
+	}
+
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got struct {
+				DependsOn dockercompose.Dependencies `yaml:"depends_on"`
+			}
+
+			if err := yaml.Unmarshal([]byte(input), &got); err == nil {
+				t.Fatalf("expected error, got %+v", got.DependsOn)
+			}
+		})
+	}
+}
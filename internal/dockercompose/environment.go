@@ -1,42 +1,63 @@
 package dockercompose
 
 import (
-	"fmt"
 	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Environment represents 'environment' directive in docker-compose file
 type Environment map[string]string
 
-// Render formats Environment as YAML string
+// Render formats Environment as YAML string. Variables are sorted by name, so the output is the same across runs
 func (e Environment) Render() string {
-	length := len(e)
+	return directive("environment", e.node())
+}
+
+func (e Environment) node() *yaml.Node {
+	m := mappingNode()
 
-	if length == 0 {
-		return ""
+	for _, variable := range sortedKeys(e) {
+		if value := e[variable]; value == "" {
+			appendPair(m, variable, nullNode())
+		} else {
+			appendPair(m, variable, stringNode(value))
+		}
 	}
 
-	var sb strings.Builder
-	sb.WriteString("environment:\n")
+	return emptyToNil(m)
+}
 
-	i := 1
-	for variable, value := range e {
-		if variable == "" {
-			continue
-		}
+// UnmarshalYAML implements yaml.Unmarshaler. Both mapping and list (VAR=value) syntax are supported
+func (e *Environment) UnmarshalYAML(value *yaml.Node) error {
+	env := Environment{}
 
-		if value == "" {
-			sb.WriteString(fmt.Sprintf("  %s:", variable))
-		} else {
-			sb.WriteString(fmt.Sprintf("  %s: %s", variable, value))
+	switch value.Kind {
+	case yaml.MappingNode:
+		if err := value.Decode((*map[string]string)(&env)); err != nil {
+			return err
 		}
+	case yaml.SequenceNode:
+		var vars []string
 
-		if i != length {
-			sb.WriteString("\n")
+		if err := value.Decode(&vars); err != nil {
+			return err
 		}
 
-		i++
+		for _, v := range vars {
+			parts := strings.SplitN(v, "=", 2)
+
+			if len(parts) == 2 {
+				env[parts[0]] = parts[1]
+			} else {
+				env[parts[0]] = ""
+			}
+		}
+	default:
+		return nodeError(value, "environment must be a mapping or a list")
 	}
 
-	return sb.String()
+	*e = env
+
+	return nil
 }
@@ -25,6 +25,56 @@ func TestEnvironment_Render(t *testing.T) {
 			want: `environment:
   SOME_VAR:`,
 		},
+		"several variables": {
+			input: dockercompose.Environment{
+				"MYSQL_USER":          "user",
+				"MYSQL_ROOT_PASSWORD": "root",
+				"MYSQL_PASSWORD":      "secret",
+				"MYSQL_DATABASE":      "db",
+			},
+			want: `environment:
+  MYSQL_DATABASE: db
+  MYSQL_PASSWORD: secret
+  MYSQL_ROOT_PASSWORD: root
+  MYSQL_USER: user`,
+		},
+		"with empty variable": {
+			input: dockercompose.Environment{
+				"":         "foo",
+				"SOME_VAR": "bar",
+			},
+			want: `environment:
+  SOME_VAR: bar`,
+		},
+		"only empty variable": {
+			input: dockercompose.Environment{
+				"": "foo",
+			},
+			want: "",
+		},
+		"with special characters": {
+			input: dockercompose.Environment{
+				"SOME_VAR": "p@ss: #word",
+			},
+			want: `environment:
+  SOME_VAR: 'p@ss: #word'`,
+		},
+		"with value which looks like a number": {
+			input: dockercompose.Environment{
+				"SOME_VAR": "5.7",
+			},
+			want: `environment:
+  SOME_VAR: "5.7"`,
+		},
+		"with multiline value": {
+			input: dockercompose.Environment{
+				"SOME_VAR": "line1\nline2",
+			},
+			want: `environment:
+  SOME_VAR: |-
+    line1
+    line2`,
+		},
 	}
 
 	for name, tc := range tests {
@@ -0,0 +1,94 @@
+package dockercompose
+
+import "gopkg.in/yaml.v3"
+
+// Healthcheck represents 'healthcheck' directive which determines whether the container of the service is healthy
+type Healthcheck struct {
+	// Test is a command in exec form prefixed with CMD or CMD-SHELL (e.g. ["CMD", "pg_isready"]). ["NONE"] disables
+	// the healthcheck of the image
+	Test        []string
+	Interval    string
+	Timeout     string
+	Retries     int
+	StartPeriod string
+}
+
+// Render formats Healthcheck as YAML string
+func (h *Healthcheck) Render() string {
+	return directive("healthcheck", h.node())
+}
+
+func (h *Healthcheck) node() *yaml.Node {
+	if h == nil || len(h.Test) == 0 {
+		return nil
+	}
+
+	test := sequenceNode()
+	test.Style = yaml.FlowStyle
+
+	for _, arg := range h.Test {
+		appendItem(test, stringNode(arg))
+	}
+
+	m := mappingNode()
+	appendPair(m, "test", test)
+
+	if h.Interval != "" {
+		appendPair(m, "interval", stringNode(h.Interval))
+	}
+
+	if h.Timeout != "" {
+		appendPair(m, "timeout", stringNode(h.Timeout))
+	}
+
+	if h.Retries != 0 {
+		appendPair(m, "retries", intNode(h.Retries))
+	}
+
+	if h.StartPeriod != "" {
+		appendPair(m, "start_period", stringNode(h.StartPeriod))
+	}
+
+	return m
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. Test in shell form is converted to CMD-SHELL
+func (h *Healthcheck) UnmarshalYAML(value *yaml.Node) error {
+	if err := checkKeys(value, "test", "interval", "timeout", "retries", "start_period", "disable"); err != nil {
+		return err
+	}
+
+	var raw struct {
+		Test        yaml.Node `yaml:"test"`
+		Interval    string    `yaml:"interval"`
+		Timeout     string    `yaml:"timeout"`
+		Retries     int       `yaml:"retries"`
+		StartPeriod string    `yaml:"start_period"`
+		Disable     bool      `yaml:"disable"`
+	}
+
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	switch {
+	case raw.Disable:
+		h.Test = []string{"NONE"}
+	case raw.Test.Kind == 0:
+	case raw.Test.Kind == yaml.ScalarNode:
+		h.Test = []string{"CMD-SHELL", raw.Test.Value}
+	case raw.Test.Kind == yaml.SequenceNode:
+		if err := raw.Test.Decode(&h.Test); err != nil {
+			return err
+		}
+	default:
+		return nodeError(&raw.Test, "healthcheck test must be a string or a list")
+	}
+
+	h.Interval = raw.Interval
+	h.Timeout = raw.Timeout
+	h.Retries = raw.Retries
+	h.StartPeriod = raw.StartPeriod
+
+	return nil
+}
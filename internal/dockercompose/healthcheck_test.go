@@ -0,0 +1,122 @@
+package dockercompose_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
+)
+
+func TestHealthcheck_Render(t *testing.T) {
+	tests := map[string]struct {
+		input *dockercompose.Healthcheck
+		want  string
+	}{
+		"full": {
+			input: &dockercompose.Healthcheck{
+				Test:        []string{"CMD", "redis-cli", "ping"},
+				Interval:    "10s",
+				Timeout:     "5s",
+				Retries:     5,
+				StartPeriod: "30s",
+			},
+			want: `healthcheck:
+  test: [CMD, redis-cli, ping]
+  interval: 10s
+  timeout: 5s
+  retries: 5
+  start_period: 30s`,
+		},
+		"only test": {
+			input: &dockercompose.Healthcheck{Test: []string{"CMD-SHELL", "pg_isready -U postgres || exit 1"}},
+			want: `healthcheck:
+  test: [CMD-SHELL, pg_isready -U postgres || exit 1]`,
+		},
+		"without test": {
+			input: &dockercompose.Healthcheck{Interval: "10s"},
+			want:  "",
+		},
+		"nil": {
+			input: nil,
+			want:  "",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.input.Render()); diff != "" {
+				t.Errorf("Healthcheck.Render() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHealthcheck_UnmarshalYAML(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  *dockercompose.Healthcheck
+	}{
+		"exec form": {
+			input: `healthcheck:
+  test: ["CMD", "mysqladmin", "ping"]
+  interval: 10s
+  timeout: 5s
+  retries: 5
+  start_period: 1m`,
+			want: &dockercompose.Healthcheck{
+				Test:        []string{"CMD", "mysqladmin", "ping"},
+				Interval:    "10s",
+				Timeout:     "5s",
+				Retries:     5,
+				StartPeriod: "1m",
+			},
+		},
+		"shell form": {
+			input: `healthcheck:
+  test: curl -f http://localhost || exit 1`,
+			want: &dockercompose.Healthcheck{Test: []string{"CMD-SHELL", "curl -f http://localhost || exit 1"}},
+		},
+		"disabled": {
+			input: `healthcheck:
+  disable: true`,
+			want: &dockercompose.Healthcheck{Test: []string{"NONE"}},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got struct {
+				Healthcheck *dockercompose.Healthcheck `yaml:"healthcheck"`
+			}
+
+			if err := yaml.Unmarshal([]byte(tc.input), &got); err != nil {
+				t.Fatalf("yaml.Unmarshal() returned error: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got.Healthcheck); diff != "" {
+				t.Errorf("Healthcheck.UnmarshalYAML() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHealthcheck_UnmarshalYAMLErrors(t *testing.T) {
+	tests := map[string]string{
+		"unsupported option": "healthcheck:\n  test: [CMD, true]\n  foo: bar",
+		"invalid test":       "healthcheck:\n  test:\n    cmd: true",
+	}
+
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got struct {
+				Healthcheck *dockercompose.Healthcheck `yaml:"healthcheck"`
+			}
+
+			if err := yaml.Unmarshal([]byte(input), &got); err == nil {
+				t.Fatalf("expected error, got %+v", got.Healthcheck)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package dockercompose
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ExtraHosts represents 'extra_hosts' directive. It maps hostnames to IP addresses which are added to /etc/hosts of
+// the container
+type ExtraHosts map[string]string
+
+// Render formats ExtraHosts as YAML string. Hosts are sorted by name, so the output is the same across runs
+func (h ExtraHosts) Render() string {
+	return directive("extra_hosts", h.node())
+}
+
+func (h ExtraHosts) node() *yaml.Node {
+	s := sequenceNode()
+
+	for _, host := range sortedKeys(h) {
+		if h[host] != "" {
+			appendItem(s, stringNode(host+":"+h[host]))
+		}
+	}
+
+	return emptyToNil(s)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. Both mapping and list (host:ip or host=ip) syntax are supported
+func (h *ExtraHosts) UnmarshalYAML(value *yaml.Node) error {
+	hosts := ExtraHosts{}
+
+	switch value.Kind {
+	case yaml.MappingNode:
+		if err := value.Decode((*map[string]string)(&hosts)); err != nil {
+			return err
+		}
+	case yaml.SequenceNode:
+		for _, item := range value.Content {
+			var entry string
+
+			if err := item.Decode(&entry); err != nil {
+				return err
+			}
+
+			// IPv6 addresses contain colons themselves, so only the first separator is taken into account
+			i := strings.IndexAny(entry, ":=")
+
+			if i <= 0 || i == len(entry)-1 {
+				return nodeError(item, "extra host %q must be in host:ip format", entry)
+			}
+
+			hosts[entry[:i]] = entry[i+1:]
+		}
+	default:
+		return nodeError(value, "extra_hosts must be a mapping or a list")
+	}
+
+	*h = hosts
+
+	return nil
+}
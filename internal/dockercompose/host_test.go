@@ -0,0 +1,92 @@
+package dockercompose_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
+)
+
+func TestExtraHosts_Render(t *testing.T) {
+	tests := map[string]struct {
+		input dockercompose.ExtraHosts
+		want  string
+	}{
+		"sorted by host": {
+			input: dockercompose.ExtraHosts{
+				"host.docker.internal": "host-gateway",
+				"api.local":            "10.0.0.2",
+				"ipv6.local":           "::1",
+			},
+			want: `extra_hosts:
+  - api.local:10.0.0.2
+  - host.docker.internal:host-gateway
+  - ipv6.local:::1`,
+		},
+		"host without address": {
+			input: dockercompose.ExtraHosts{"api.local": ""},
+			want:  "",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.input.Render()); diff != "" {
+				t.Errorf("ExtraHosts.Render() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExtraHosts_UnmarshalYAML(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    dockercompose.ExtraHosts
+		wantErr bool
+	}{
+		"list": {
+			input: `extra_hosts: ["api.local:10.0.0.2", "ipv6.local:::1", "db.local=10.0.0.3"]`,
+			want: dockercompose.ExtraHosts{
+				"api.local":  "10.0.0.2",
+				"ipv6.local": "::1",
+				"db.local":   "10.0.0.3",
+			},
+		},
+		"mapping": {
+			input: "extra_hosts:\n  api.local: 10.0.0.2",
+			want:  dockercompose.ExtraHosts{"api.local": "10.0.0.2"},
+		},
+		"without address": {
+			input:   "extra_hosts: [api.local]",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got struct {
+				ExtraHosts dockercompose.ExtraHosts `yaml:"extra_hosts"`
+			}
+
+			err := yaml.Unmarshal([]byte(tc.input), &got)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got.ExtraHosts)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("yaml.Unmarshal() returned error: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got.ExtraHosts); diff != "" {
+				t.Errorf("ExtraHosts.UnmarshalYAML() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
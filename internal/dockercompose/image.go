@@ -1,28 +1,70 @@
 package dockercompose
 
-import "strings"
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
 
 // Image represents 'image' directive in docker-compose file
 type Image struct {
 	Name string
 	Tag  string
+	// Digest pins the image to the exact content (e.g. sha256:abc). Tag is kept for readability when both are set
+	Digest string
 }
 
 // Render formats Image as YAML string
 func (i *Image) Render() string {
+	return directive("image", i.node())
+}
+
+func (i *Image) node() *yaml.Node {
 	if i.Name == "" {
-		return ""
+		return nil
 	}
 
-	var sb strings.Builder
-	sb.WriteString("image: ")
+	return stringNode(i.Reference())
+}
+
+// Reference returns image reference in the form it is written in docker-compose file and Dockerfile
+func (i *Image) Reference() string {
+	if i.Digest == "" {
+		return mapping(i.Name, i.Tag)
+	}
+
+	return mapping(i.Name, i.Tag) + "@" + i.Digest
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (i *Image) UnmarshalYAML(value *yaml.Node) error {
+	var image string
 
-	if i.Tag == "" {
-		sb.WriteString(i.Name)
-		return sb.String()
+	if err := value.Decode(&image); err != nil {
+		return err
 	}
 
-	sb.WriteString(mapping(i.Name, i.Tag))
+	*i = *ParseImage(image)
+
+	return nil
+}
+
+// ParseImage splits image reference into name, tag and digest. Colon of the registry port (e.g. localhost:5000/app)
+// is not treated as a tag separator
+func ParseImage(ref string) *Image {
+	image := &Image{}
+
+	if sep := strings.Index(ref, "@"); sep != -1 {
+		ref, image.Digest = ref[:sep], ref[sep+1:]
+	}
+
+	sep := strings.LastIndex(ref, ":")
+
+	if sep == -1 || strings.Contains(ref[sep+1:], "/") {
+		image.Name = ref
+	} else {
+		image.Name, image.Tag = ref[:sep], ref[sep+1:]
+	}
 
-	return sb.String()
+	return image
 }
@@ -3,6 +3,8 @@ package dockercompose_test
 import (
 	"testing"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
 )
 
@@ -19,6 +21,10 @@ func TestImage_Render(t *testing.T) {
 			input: dockercompose.Image{Name: "nginx"},
 			want:  "image: nginx",
 		},
+		"pinned by digest": {
+			input: dockercompose.Image{Name: "nginx", Tag: "alpine", Digest: "sha256:abc"},
+			want:  "image: nginx:alpine@sha256:abc",
+		},
 		"no tag and no name": {
 			input: dockercompose.Image{},
 			want:  "",
@@ -34,3 +40,31 @@ func TestImage_Render(t *testing.T) {
 		})
 	}
 }
+
+func TestImage_UnmarshalYAML(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  dockercompose.Image
+	}{
+		"with tag":                   {input: "nginx:alpine", want: dockercompose.Image{Name: "nginx", Tag: "alpine"}},
+		"without tag":                {input: "nginx", want: dockercompose.Image{Name: "nginx"}},
+		"registry with port":         {input: "localhost:5000/app", want: dockercompose.Image{Name: "localhost:5000/app"}},
+		"registry with port and tag": {input: "localhost:5000/app:1.0", want: dockercompose.Image{Name: "localhost:5000/app", Tag: "1.0"}},
+		"pinned by digest":           {input: "nginx@sha256:abc", want: dockercompose.Image{Name: "nginx", Digest: "sha256:abc"}},
+		"tag and digest":             {input: "nginx:alpine@sha256:abc", want: dockercompose.Image{Name: "nginx", Tag: "alpine", Digest: "sha256:abc"}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got dockercompose.Image
+
+			if err := yaml.Unmarshal([]byte(tc.input), &got); err != nil {
+				t.Fatalf("failed to unmarshal image: %s", err)
+			}
+
+			if tc.want != got {
+				t.Fatalf("expected: %v, got: %v", tc.want, got)
+			}
+		})
+	}
+}
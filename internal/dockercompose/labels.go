@@ -0,0 +1,59 @@
+package dockercompose
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Labels represents 'labels' directive which adds metadata to the container
+type Labels map[string]string
+
+// Render formats Labels as YAML string. Labels are sorted by name, so the output is the same across runs
+func (l Labels) Render() string {
+	return directive("labels", l.node())
+}
+
+func (l Labels) node() *yaml.Node {
+	m := mappingNode()
+
+	for _, label := range sortedKeys(l) {
+		appendPair(m, label, stringNode(l[label]))
+	}
+
+	return emptyToNil(m)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. Both mapping and list (label=value) syntax are supported
+func (l *Labels) UnmarshalYAML(value *yaml.Node) error {
+	labels := Labels{}
+
+	switch value.Kind {
+	case yaml.MappingNode:
+		if err := value.Decode((*map[string]string)(&labels)); err != nil {
+			return err
+		}
+	case yaml.SequenceNode:
+		var items []string
+
+		if err := value.Decode(&items); err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			parts := strings.SplitN(item, "=", 2)
+
+			if len(parts) == 2 {
+				labels[parts[0]] = parts[1]
+			} else {
+				labels[parts[0]] = ""
+			}
+		}
+	default:
+		return nodeError(value, "labels must be a mapping or a list")
+	}
+
+	*l = labels
+
+	return nil
+}
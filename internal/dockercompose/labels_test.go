@@ -0,0 +1,73 @@
+package dockercompose_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
+)
+
+func TestLabels_Render(t *testing.T) {
+	tests := map[string]struct {
+		input dockercompose.Labels
+		want  string
+	}{
+		"sorted by name": {
+			input: dockercompose.Labels{
+				"traefik.http.routers.app.rule": "Host(`app.local`)",
+				"com.example.team":              "backend",
+				"com.example.empty":             "",
+			},
+			want: `labels:
+  com.example.empty: ""
+  com.example.team: backend
+  traefik.http.routers.app.rule: Host(` + "`app.local`" + `)`,
+		},
+		"empty": {
+			input: dockercompose.Labels{},
+			want:  "",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.input.Render()); diff != "" {
+				t.Errorf("Labels.Render() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLabels_UnmarshalYAML(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  dockercompose.Labels
+	}{
+		"mapping": {
+			input: "labels:\n  com.example.team: backend\n  com.example.tier: \"1\"",
+			want:  dockercompose.Labels{"com.example.team": "backend", "com.example.tier": "1"},
+		},
+		"list": {
+			input: "labels:\n  - com.example.team=backend\n  - com.example.flag",
+			want:  dockercompose.Labels{"com.example.team": "backend", "com.example.flag": ""},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got struct {
+				Labels dockercompose.Labels `yaml:"labels"`
+			}
+
+			if err := yaml.Unmarshal([]byte(tc.input), &got); err != nil {
+				t.Fatalf("yaml.Unmarshal() returned error: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got.Labels); diff != "" {
+				t.Errorf("Labels.UnmarshalYAML() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
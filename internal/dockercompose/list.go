@@ -0,0 +1,38 @@
+package dockercompose
+
+import "gopkg.in/yaml.v3"
+
+// StringList represents directives which hold a list of plain strings (e.g. 'cap_add', 'profiles', 'env_file')
+type StringList []string
+
+func (l StringList) node() *yaml.Node {
+	s := sequenceNode()
+
+	for _, item := range l {
+		if item != "" {
+			appendItem(s, stringNode(item))
+		}
+	}
+
+	return emptyToNil(s)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. A single string is treated as a list with one item
+func (l *StringList) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		*l = StringList{value.Value}
+	case yaml.SequenceNode:
+		var items []string
+
+		if err := value.Decode(&items); err != nil {
+			return err
+		}
+
+		*l = items
+	default:
+		return nodeError(value, "expected a string or a list")
+	}
+
+	return nil
+}
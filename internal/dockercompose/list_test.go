@@ -0,0 +1,57 @@
+package dockercompose_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
+)
+
+func TestStringList_UnmarshalYAML(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    dockercompose.StringList
+		wantErr bool
+	}{
+		"single string": {
+			input: "list: .env",
+			want:  dockercompose.StringList{".env"},
+		},
+		"list": {
+			input: "list: [.env, .env.local]",
+			want:  dockercompose.StringList{".env", ".env.local"},
+		},
+		"mapping": {
+			input:   "list:\n  path: .env",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got struct {
+				List dockercompose.StringList `yaml:"list"`
+			}
+
+			err := yaml.Unmarshal([]byte(tc.input), &got)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got.List)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("yaml.Unmarshal() returned error: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got.List); diff != "" {
+				t.Errorf("StringList.UnmarshalYAML() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
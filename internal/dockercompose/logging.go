@@ -0,0 +1,57 @@
+package dockercompose
+
+import "gopkg.in/yaml.v3"
+
+// Logging represents 'logging' directive which configures the logging driver of the container
+type Logging struct {
+	Driver  string
+	Options map[string]string
+}
+
+// Render formats Logging as YAML string. Options are sorted by name, so the output is the same across runs
+func (l *Logging) Render() string {
+	return directive("logging", l.node())
+}
+
+func (l *Logging) node() *yaml.Node {
+	if l == nil {
+		return nil
+	}
+
+	m := mappingNode()
+
+	if l.Driver != "" {
+		appendPair(m, "driver", stringNode(l.Driver))
+	}
+
+	options := mappingNode()
+
+	for _, option := range sortedKeys(l.Options) {
+		appendPair(options, option, stringNode(l.Options[option]))
+	}
+
+	appendPair(m, "options", emptyToNil(options))
+
+	return emptyToNil(m)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (l *Logging) UnmarshalYAML(value *yaml.Node) error {
+	if err := checkKeys(value, "driver", "options"); err != nil {
+		return err
+	}
+
+	var raw struct {
+		Driver  string            `yaml:"driver"`
+		Options map[string]string `yaml:"options"`
+	}
+
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	l.Driver = raw.Driver
+	l.Options = raw.Options
+
+	return nil
+}
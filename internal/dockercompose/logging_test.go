@@ -0,0 +1,71 @@
+package dockercompose_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
+)
+
+func TestLogging_Render(t *testing.T) {
+	tests := map[string]struct {
+		input *dockercompose.Logging
+		want  string
+	}{
+		"driver with options": {
+			input: &dockercompose.Logging{
+				Driver:  "json-file",
+				Options: map[string]string{"max-size": "10m", "max-file": "3"},
+			},
+			want: `logging:
+  driver: json-file
+  options:
+    max-file: "3"
+    max-size: 10m`,
+		},
+		"only driver": {
+			input: &dockercompose.Logging{Driver: "none"},
+			want: `logging:
+  driver: none`,
+		},
+		"nil": {
+			input: nil,
+			want:  "",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.input.Render()); diff != "" {
+				t.Errorf("Logging.Render() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLogging_UnmarshalYAML(t *testing.T) {
+	var got struct {
+		Logging *dockercompose.Logging `yaml:"logging"`
+	}
+
+	input := "logging:\n  driver: syslog\n  options:\n    syslog-address: tcp://192.168.0.42:123"
+
+	if err := yaml.Unmarshal([]byte(input), &got); err != nil {
+		t.Fatalf("yaml.Unmarshal() returned error: %s", err)
+	}
+
+	want := &dockercompose.Logging{
+		Driver:  "syslog",
+		Options: map[string]string{"syslog-address": "tcp://192.168.0.42:123"},
+	}
+
+	if diff := cmp.Diff(want, got.Logging); diff != "" {
+		t.Errorf("Logging.UnmarshalYAML() mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := yaml.Unmarshal([]byte("logging:\n  type: syslog"), &got); err == nil {
+		t.Errorf("expected error for unsupported option")
+	}
+}
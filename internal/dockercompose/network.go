@@ -1,9 +1,6 @@
 package dockercompose
 
-import (
-	"fmt"
-	"strings"
-)
+import "gopkg.in/yaml.v3"
 
 // NetworkDriver is one of the network drivers supported by docker
 type NetworkDriver string
@@ -21,40 +18,174 @@ const (
 type Network struct {
 	Name   string
 	Driver NetworkDriver
+	// External network is created outside of the compose project (e.g. shared by several projects), so only its name
+	// is rendered
+	External bool
+	IPAM     *IPAM
 }
 
 // Render formats Network as YAML string
 func (n *Network) Render() string {
-	if n.Name == "" || n.Driver == "" {
+	if n.Name == "" {
 		return ""
 	}
 
-	return fmt.Sprintf("%s:\n  driver: %s", n.Name, n.Driver)
+	return directive(n.Name, n.node())
+}
+
+func (n *Network) node() *yaml.Node {
+	if n.Name == "" {
+		return nil
+	}
+
+	m := mappingNode()
+
+	if n.External {
+		appendPair(m, "external", boolNode(true))
+
+		return m
+	}
+
+	if n.Driver != "" {
+		appendPair(m, "driver", stringNode(string(n.Driver)))
+	}
+
+	appendPair(m, "ipam", n.IPAM.node())
+
+	// Network without options is still declared, so services can be attached to it
+	if len(m.Content) == 0 {
+		return nullNode()
+	}
+
+	return m
+}
+
+// IPAM is IP address management of the network
+type IPAM struct {
+	// Driver is the default one when empty
+	Driver string
+	Config []*IPAMPool
+}
+
+// IPAMPool is a block of addresses the network allocates container addresses from
+type IPAMPool struct {
+	Subnet  string
+	Gateway string
+	IPRange string
+}
+
+func (i *IPAM) node() *yaml.Node {
+	if i == nil {
+		return nil
+	}
+
+	m := mappingNode()
+
+	if i.Driver != "" {
+		appendPair(m, "driver", stringNode(i.Driver))
+	}
+
+	config := sequenceNode()
+
+	for _, pool := range i.Config {
+		appendItem(config, pool.node())
+	}
+
+	appendPair(m, "config", emptyToNil(config))
+
+	return emptyToNil(m)
+}
+
+func (p *IPAMPool) node() *yaml.Node {
+	m := mappingNode()
+
+	if p.Subnet != "" {
+		appendPair(m, "subnet", stringNode(p.Subnet))
+	}
+
+	if p.Gateway != "" {
+		appendPair(m, "gateway", stringNode(p.Gateway))
+	}
+
+	if p.IPRange != "" {
+		appendPair(m, "ip_range", stringNode(p.IPRange))
+	}
+
+	return emptyToNil(m)
+}
+
+// ServiceNetwork is a top-level network the service is attached to
+type ServiceNetwork struct {
+	*Network
+	// Aliases are additional hostnames of the service within the network
+	Aliases     []string
+	IPv4Address string
+}
+
+func (n *ServiceNetwork) hasOptions() bool {
+	return len(n.Aliases) != 0 || n.IPv4Address != ""
+}
+
+func (n *ServiceNetwork) options() *yaml.Node {
+	m := mappingNode()
+
+	if len(n.Aliases) != 0 {
+		aliases := sequenceNode()
+
+		for _, alias := range n.Aliases {
+			appendItem(aliases, stringNode(alias))
+		}
+
+		appendPair(m, "aliases", aliases)
+	}
+
+	if n.IPv4Address != "" {
+		appendPair(m, "ipv4_address", stringNode(n.IPv4Address))
+	}
+
+	if len(m.Content) == 0 {
+		return nullNode()
+	}
+
+	return m
 }
 
 // ServiceNetworks is service-level networks
-type ServiceNetworks []*Network
+type ServiceNetworks []*ServiceNetwork
 
-// Render formats ServiceNetworks as YAML string
+// Render formats ServiceNetworks as YAML string. Networks are rendered as a list unless any of them has options
 func (n ServiceNetworks) Render() string {
-	length := len(n)
+	return directive("networks", n.node())
+}
 
-	if length == 0 {
-		return ""
+func (n ServiceNetworks) node() *yaml.Node {
+	withOptions := false
+
+	for _, network := range n {
+		withOptions = withOptions || network.hasOptions()
 	}
 
-	var sb strings.Builder
-	sb.WriteString("networks:\n")
+	if withOptions {
+		m := mappingNode()
+
+		for _, network := range n {
+			if network.Name != "" {
+				appendPair(m, network.Name, network.options())
+			}
+		}
 
-	for i, network := range n {
-		sb.WriteString(fmt.Sprintf("  - %s", network.Name))
+		return emptyToNil(m)
+	}
 
-		if i+1 != length {
-			sb.WriteString("\n")
+	seq := sequenceNode()
+
+	for _, network := range n {
+		if network.Name != "" {
+			appendItem(seq, stringNode(network.Name))
 		}
 	}
 
-	return sb.String()
+	return emptyToNil(seq)
 }
 
 // Networks is a top-level networks directive
@@ -62,15 +193,17 @@ type Networks []*Network
 
 // Render formats Networks as YAML string
 func (n Networks) Render() string {
-	var sb strings.Builder
+	return marshal(n.node())
+}
 
-	if len(n) != 0 {
-		for _, vol := range n {
-			sb.WriteString(vol.Render())
-		}
+func (n Networks) node() *yaml.Node {
+	m := mappingNode()
+
+	for _, network := range n {
+		appendPair(m, network.Name, network.node())
 	}
 
-	return sb.String()
+	return emptyToNil(m)
 }
 
 // IsEmpty checks if Networks has zero networks
@@ -80,5 +213,174 @@ func (n Networks) IsEmpty() bool {
 
 // ToServiceNetworks transforms top-level Networks to service-level ServiceNetworks
 func (n Networks) ToServiceNetworks() ServiceNetworks {
-	return ServiceNetworks(n)
+	networks := make(ServiceNetworks, 0, len(n))
+
+	for _, network := range n {
+		networks = append(networks, &ServiceNetwork{Network: network})
+	}
+
+	return networks
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. Driver is left untouched when it is not specified
+func (n *Network) UnmarshalYAML(value *yaml.Node) error {
+	if value.Tag == "!!null" {
+		return nil
+	}
+
+	if err := checkKeys(value, "driver", "external", "ipam"); err != nil {
+		return err
+	}
+
+	var raw struct {
+		Driver   NetworkDriver `yaml:"driver"`
+		External bool          `yaml:"external"`
+		IPAM     *IPAM         `yaml:"ipam"`
+	}
+
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	if raw.Driver != "" {
+		n.Driver = raw.Driver
+	}
+
+	if raw.External {
+		n.External = true
+		n.Driver = ""
+	}
+
+	n.IPAM = raw.IPAM
+
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (i *IPAM) UnmarshalYAML(value *yaml.Node) error {
+	if err := checkKeys(value, "driver", "config"); err != nil {
+		return err
+	}
+
+	var raw struct {
+		Driver string    `yaml:"driver"`
+		Config yaml.Node `yaml:"config"`
+	}
+
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	i.Driver = raw.Driver
+
+	for _, item := range raw.Config.Content {
+		if err := checkKeys(item, "subnet", "gateway", "ip_range"); err != nil {
+			return err
+		}
+
+		pool := struct {
+			Subnet  string `yaml:"subnet"`
+			Gateway string `yaml:"gateway"`
+			IPRange string `yaml:"ip_range"`
+		}{}
+
+		if err := item.Decode(&pool); err != nil {
+			return err
+		}
+
+		i.Config = append(i.Config, &IPAMPool{Subnet: pool.Subnet, Gateway: pool.Gateway, IPRange: pool.IPRange})
+	}
+
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. Both list and mapping syntax are supported, though only mapping syntax
+// can have aliases and an address. Networks of the service have only names set until Config resolves them
+func (n *ServiceNetworks) UnmarshalYAML(value *yaml.Node) error {
+	networks := ServiceNetworks{}
+
+	switch value.Kind {
+	case yaml.SequenceNode:
+		var names []string
+
+		if err := value.Decode(&names); err != nil {
+			return err
+		}
+
+		for _, name := range names {
+			networks = append(networks, &ServiceNetwork{Network: &Network{Name: name}})
+		}
+	case yaml.MappingNode:
+		for i := 0; i < len(value.Content); i += 2 {
+			network := &ServiceNetwork{Network: &Network{Name: value.Content[i].Value}}
+
+			if opts := value.Content[i+1]; opts.Tag != "!!null" {
+				if err := network.decodeOptions(opts); err != nil {
+					return err
+				}
+			}
+
+			networks = append(networks, network)
+		}
+	default:
+		return nodeError(value, "networks must be a list or a mapping")
+	}
+
+	*n = networks
+
+	return nil
+}
+
+func (n *ServiceNetwork) decodeOptions(value *yaml.Node) error {
+	if err := checkKeys(value, "aliases", "ipv4_address"); err != nil {
+		return err
+	}
+
+	var raw struct {
+		Aliases     []string `yaml:"aliases"`
+		IPv4Address string   `yaml:"ipv4_address"`
+	}
+
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	n.Aliases = raw.Aliases
+	n.IPv4Address = raw.IPv4Address
+
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. Networks are kept in the order of declaration and use bridge driver
+// unless specified otherwise
+func (n *Networks) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.MappingNode {
+		return nodeError(value, "networks must be a mapping")
+	}
+
+	networks := Networks{}
+
+	for i := 0; i < len(value.Content); i += 2 {
+		network := &Network{Name: value.Content[i].Value, Driver: NetworkDriverBridge}
+
+		if err := value.Content[i+1].Decode(network); err != nil {
+			return err
+		}
+
+		networks = append(networks, network)
+	}
+
+	*n = networks
+
+	return nil
+}
+
+func (n Networks) find(name string) *Network {
+	for _, network := range n {
+		if network.Name == name {
+			return network
+		}
+	}
+
+	return nil
 }
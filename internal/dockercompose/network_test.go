@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
 
 	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
 )
@@ -19,7 +20,7 @@ func TestNetwork_String(t *testing.T) {
   driver: bridge`},
 		"no driver": {
 			input: dockercompose.Network{Name: "service-network"},
-			want:  "",
+			want:  "service-network:",
 		},
 		"no name": {
 			input: dockercompose.Network{Driver: dockercompose.NetworkDriverHost},
@@ -29,6 +30,32 @@ func TestNetwork_String(t *testing.T) {
 			input: dockercompose.Network{},
 			want:  "",
 		},
+		"external": {
+			input: dockercompose.Network{Name: "proxy", Driver: dockercompose.NetworkDriverBridge, External: true},
+			want: `proxy:
+  external: true`},
+		"ipam": {
+			input: dockercompose.Network{
+				Name:   "backend",
+				Driver: dockercompose.NetworkDriverBridge,
+				IPAM: &dockercompose.IPAM{
+					Config: []*dockercompose.IPAMPool{
+						{Subnet: "172.28.0.0/16", Gateway: "172.28.0.1", IPRange: "172.28.5.0/24"},
+					},
+				},
+			},
+			want: `backend:
+  driver: bridge
+  ipam:
+    config:
+      - subnet: 172.28.0.0/16
+        gateway: 172.28.0.1
+        ip_range: 172.28.5.0/24`},
+		"ipam driver": {
+			input: dockercompose.Network{Name: "backend", IPAM: &dockercompose.IPAM{Driver: "default"}},
+			want: `backend:
+  ipam:
+    driver: default`},
 	}
 
 	for name, tc := range tests {
@@ -48,12 +75,28 @@ func TestNetworks_Render(t *testing.T) {
 	}{
 		"simple": {
 			input: dockercompose.ServiceNetworks{
-				&dockercompose.Network{Name: "test-data", Driver: dockercompose.NetworkDriverBridge},
-				&dockercompose.Network{Name: "test-data-1", Driver: dockercompose.NetworkDriverHost},
+				{Network: &dockercompose.Network{Name: "test-data", Driver: dockercompose.NetworkDriverBridge}},
+				{Network: &dockercompose.Network{Name: "test-data-1", Driver: dockercompose.NetworkDriverHost}},
 			},
 			want: `networks:
   - test-data
   - test-data-1`},
+		"aliases": {
+			input: dockercompose.ServiceNetworks{
+				{Network: &dockercompose.Network{Name: "app-network", Driver: dockercompose.NetworkDriverBridge}},
+				{
+					Network:     &dockercompose.Network{Name: "proxy", External: true},
+					Aliases:     []string{"app.test", "www.app.test"},
+					IPv4Address: "172.30.0.10",
+				},
+			},
+			want: `networks:
+  app-network:
+  proxy:
+    aliases:
+      - app.test
+      - www.app.test
+    ipv4_address: 172.30.0.10`},
 		"empty": {
 			input: dockercompose.ServiceNetworks{},
 			want:  "",
@@ -81,8 +124,8 @@ func TestNetworks_ToServiceNetworks(t *testing.T) {
 				&dockercompose.Network{Driver: dockercompose.NetworkDriverBridge, Name: "test-network2"},
 			},
 			want: dockercompose.ServiceNetworks{
-				&dockercompose.Network{Driver: dockercompose.NetworkDriverBridge, Name: "test-network"},
-				&dockercompose.Network{Driver: dockercompose.NetworkDriverBridge, Name: "test-network2"},
+				{Network: &dockercompose.Network{Driver: dockercompose.NetworkDriverBridge, Name: "test-network"}},
+				{Network: &dockercompose.Network{Driver: dockercompose.NetworkDriverBridge, Name: "test-network2"}},
 			},
 		},
 	}
@@ -96,3 +139,61 @@ func TestNetworks_ToServiceNetworks(t *testing.T) {
 		})
 	}
 }
+
+func TestTopLevelNetworks_Render(t *testing.T) {
+	input := dockercompose.Networks{
+		&dockercompose.Network{Name: "app-network", Driver: dockercompose.NetworkDriverBridge},
+		&dockercompose.Network{Name: "proxy", External: true},
+		&dockercompose.Network{Name: "default-driver"},
+	}
+
+	want := `app-network:
+  driver: bridge
+proxy:
+  external: true
+default-driver:`
+
+	if got := input.Render(); got != want {
+		t.Fatalf("expected: %v, got: %v", want, got)
+	}
+}
+
+func TestServiceNetworks_UnmarshalYAML(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  dockercompose.ServiceNetworks
+	}{
+		"list": {
+			input: "- app-network\n- proxy",
+			want: dockercompose.ServiceNetworks{
+				{Network: &dockercompose.Network{Name: "app-network"}},
+				{Network: &dockercompose.Network{Name: "proxy"}},
+			},
+		},
+		"mapping": {
+			input: "app-network:\nproxy:\n  aliases:\n    - app.test\n  ipv4_address: 172.30.0.10",
+			want: dockercompose.ServiceNetworks{
+				{Network: &dockercompose.Network{Name: "app-network"}},
+				{
+					Network:     &dockercompose.Network{Name: "proxy"},
+					Aliases:     []string{"app.test"},
+					IPv4Address: "172.30.0.10",
+				},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got dockercompose.ServiceNetworks
+
+			if err := yaml.Unmarshal([]byte(tc.input), &got); err != nil {
+				t.Fatalf("unmarshal: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ServiceNetworks.UnmarshalYAML() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -4,6 +4,17 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PortProtocol is a transport protocol of the port
+type PortProtocol string
+
+// All supported port protocols
+const (
+	PortProtocolTCP PortProtocol = "tcp"
+	PortProtocolUDP PortProtocol = "udp"
 )
 
 // Ports represents 'ports' directive in docker-compose file
@@ -11,41 +22,316 @@ type Ports []*PortsMapping
 
 // Render formats Ports as YAML string
 func (p Ports) Render() string {
-	length := len(p)
-
-	if length == 0 {
-		return ""
-	}
+	return directive("ports", p.node())
+}
 
-	var sb strings.Builder
-	sb.WriteString("ports:\n")
+func (p Ports) node() *yaml.Node {
+	seq := sequenceNode()
 
-	for i, m := range p {
-		if i+1 == length {
-			_, _ = fmt.Fprintf(&sb, "  - %s", m.Render())
-		} else {
-			_, _ = fmt.Fprintf(&sb, "  - %s\n", m.Render())
-		}
+	for _, m := range p {
+		appendItem(seq, m.node())
 	}
 
-	return sb.String()
+	return emptyToNil(seq)
 }
 
-// PortsMapping represents a single mapping of host port to container port
+// PortsMapping represents a single mapping of host port to container port. Ranges of ports are mapped when HostEnd
+// and ContainerEnd are set
 type PortsMapping struct {
-	Host      int
-	Container int
+	// HostIP is an address of the host interface the port is published on. All interfaces are used when empty
+	HostIP       string
+	Host         int
+	HostEnd      int
+	Container    int
+	ContainerEnd int
+	// Protocol is tcp when empty
+	Protocol PortProtocol
 }
 
 // Render formats PortsMapping as YAML string
 func (m *PortsMapping) Render() string {
+	return marshal(m.node())
+}
+
+// Ports are always quoted, because YAML 1.1 parsers read unquoted "xx:yy" as a base 60 number when both parts are below 60
+func (m *PortsMapping) node() *yaml.Node {
 	if m.Container == 0 {
-		return ""
+		return nil
+	}
+
+	var short string
+
+	switch {
+	case m.Host != 0:
+		short = mapping(portRange(m.Host, m.HostEnd), m.target())
+	case m.HostIP != "":
+		// Host IP without host port publishes the port on a random port of the interface
+		short = ":" + m.target()
+	default:
+		short = m.target()
+	}
+
+	if m.HostIP != "" {
+		short = mapping(formatHostIP(m.HostIP), short)
+	}
+
+	return quotedNode(short)
+}
+
+// target formats container part of the mapping with protocol (e.g. "53/udp")
+func (m *PortsMapping) target() string {
+	target := portRange(m.Container, m.ContainerEnd)
+
+	if m.Protocol != "" && m.Protocol != PortProtocolTCP {
+		target += "/" + string(m.Protocol)
 	}
 
+	return target
+}
+
+// IsRange checks if the mapping publishes a range of ports
+func (m *PortsMapping) IsRange() bool {
+	return m.HostEnd != 0 || m.ContainerEnd != 0
+}
+
+// HostPorts returns host ports published by the mapping. Nil is returned when the port is published on a random port
+func (m *PortsMapping) HostPorts() []int {
 	if m.Host == 0 {
-		return doubleQuotted(strconv.Itoa(m.Container))
+		return nil
+	}
+
+	if m.HostEnd < m.Host {
+		return []int{m.Host}
+	}
+
+	ports := make([]int, 0, m.HostEnd-m.Host+1)
+
+	for port := m.Host; port <= m.HostEnd; port++ {
+		ports = append(ports, port)
+	}
+
+	return ports
+}
+
+func portRange(start, end int) string {
+	if end == 0 || end == start {
+		return strconv.Itoa(start)
+	}
+
+	return fmt.Sprintf("%d-%d", start, end)
+}
+
+func formatHostIP(ip string) string {
+	if strings.Contains(ip, ":") {
+		return "[" + ip + "]"
+	}
+
+	return ip
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. Both short ("ip:host:container/protocol") and long syntax are supported
+func (m *PortsMapping) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		return m.parseShortSyntax(value)
+	}
+
+	if err := checkKeys(value, "target", "published", "host_ip", "protocol", "mode"); err != nil {
+		return err
+	}
+
+	var raw struct {
+		Target    int          `yaml:"target"`
+		Published string       `yaml:"published"`
+		HostIP    string       `yaml:"host_ip"`
+		Protocol  PortProtocol `yaml:"protocol"`
+	}
+
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	if raw.Target == 0 {
+		return nodeError(value, "port target is required")
+	}
+
+	if err := checkProtocol(value, raw.Protocol); err != nil {
+		return err
+	}
+
+	m.Container = raw.Target
+	m.HostIP = raw.HostIP
+	m.Protocol = raw.Protocol
+
+	if raw.Published != "" {
+		start, end, err := parsePortRange(raw.Published)
+
+		if err != nil {
+			return nodeError(value, "unsupported published port %q", raw.Published)
+		}
+
+		m.Host, m.HostEnd = start, end
+	}
+
+	return nil
+}
+
+func (m *PortsMapping) parseShortSyntax(value *yaml.Node) error {
+	spec := value.Value
+	unsupported := nodeError(value, "unsupported port mapping %q", value.Value)
+
+	if i := strings.LastIndex(spec, "/"); i != -1 {
+		m.Protocol = PortProtocol(spec[i+1:])
+		spec = spec[:i]
+
+		if err := checkProtocol(value, m.Protocol); err != nil {
+			return err
+		}
 	}
 
-	return doubleQuotted(mapping(strconv.Itoa(m.Host), strconv.Itoa(m.Container)))
+	if strings.HasPrefix(spec, "[") {
+		end := strings.Index(spec, "]:")
+
+		if end == -1 {
+			return unsupported
+		}
+
+		m.HostIP = spec[1:end]
+		spec = spec[end+2:]
+
+		// "[::1]:80" publishes container port 80 on a random port of the interface
+		if !strings.Contains(spec, ":") {
+			spec = ":" + spec
+		}
+	}
+
+	parts := strings.Split(spec, ":")
+
+	switch len(parts) {
+	case 1:
+	case 2:
+		if parts[0] != "" {
+			start, end, err := parsePortRange(parts[0])
+
+			if err != nil {
+				return unsupported
+			}
+
+			m.Host, m.HostEnd = start, end
+		}
+	case 3:
+		if m.HostIP != "" {
+			return unsupported
+		}
+
+		m.HostIP = parts[0]
+
+		if parts[1] != "" {
+			start, end, err := parsePortRange(parts[1])
+
+			if err != nil {
+				return unsupported
+			}
+
+			m.Host, m.HostEnd = start, end
+		}
+	default:
+		return unsupported
+	}
+
+	start, end, err := parsePortRange(parts[len(parts)-1])
+
+	if err != nil {
+		return unsupported
+	}
+
+	m.Container, m.ContainerEnd = start, end
+
+	if m.HostEnd != 0 && m.ContainerEnd != 0 && m.HostEnd-m.Host != m.ContainerEnd-m.Container {
+		return nodeError(value, "host and container port ranges of %q have different sizes", value.Value)
+	}
+
+	return nil
+}
+
+// parsePortRange parses a single port ("80") or a range of ports ("8000-8010"). End of a single port is zero
+func parsePortRange(value string) (int, int, error) {
+	parts := strings.SplitN(value, "-", 2)
+
+	start, err := strconv.Atoi(parts[0])
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if len(parts) == 1 {
+		return start, 0, nil
+	}
+
+	end, err := strconv.Atoi(parts[1])
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if end < start {
+		return 0, 0, fmt.Errorf("port range %s is reversed", value)
+	}
+
+	return start, end, nil
+}
+
+func checkProtocol(value *yaml.Node, protocol PortProtocol) error {
+	if protocol != "" && protocol != PortProtocolTCP && protocol != PortProtocolUDP {
+		return nodeError(value, "unsupported port protocol %q", protocol)
+	}
+
+	return nil
+}
+
+// Expose represents 'expose' directive. Exposed ports are reachable by other services but are not published on the
+// host, so only container part of the mapping is used
+type Expose []*PortsMapping
+
+// Render formats Expose as YAML string
+func (e Expose) Render() string {
+	return directive("expose", e.node())
+}
+
+func (e Expose) node() *yaml.Node {
+	seq := sequenceNode()
+
+	for _, m := range e {
+		if m.Container != 0 {
+			appendItem(seq, quotedNode(m.target()))
+		}
+	}
+
+	return emptyToNil(seq)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (e *Expose) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.SequenceNode {
+		return nodeError(value, "expose must be a list")
+	}
+
+	exposed := Expose{}
+
+	for _, item := range value.Content {
+		m := &PortsMapping{}
+
+		if item.Kind != yaml.ScalarNode || strings.Contains(item.Value, ":") {
+			return nodeError(item, "unsupported exposed port %q", item.Value)
+		}
+
+		if err := m.parseShortSyntax(item); err != nil {
+			return err
+		}
+
+		exposed = append(exposed, m)
+	}
+
+	*e = exposed
+
+	return nil
 }
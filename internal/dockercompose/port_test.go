@@ -3,6 +3,9 @@ package dockercompose_test
 import (
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+
 	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
 )
 
@@ -15,6 +18,34 @@ func TestPortsMapping_Render(t *testing.T) {
 		"empty host":               {input: &dockercompose.PortsMapping{Container: 8000}, want: `"8000"`},
 		"empty container":          {input: &dockercompose.PortsMapping{Host: 80}, want: ""},
 		"empty host and container": {input: &dockercompose.PortsMapping{}, want: ""},
+		"host ip": {
+			input: &dockercompose.PortsMapping{HostIP: "127.0.0.1", Host: 3306, Container: 3306},
+			want:  `"127.0.0.1:3306:3306"`,
+		},
+		"host ip without host port": {
+			input: &dockercompose.PortsMapping{HostIP: "127.0.0.1", Container: 3306},
+			want:  `"127.0.0.1::3306"`,
+		},
+		"ipv6 host ip": {
+			input: &dockercompose.PortsMapping{HostIP: "::1", Host: 6001, Container: 6001},
+			want:  `"[::1]:6001:6001"`,
+		},
+		"udp": {
+			input: &dockercompose.PortsMapping{Host: 5353, Container: 53, Protocol: dockercompose.PortProtocolUDP},
+			want:  `"5353:53/udp"`,
+		},
+		"tcp is implied": {
+			input: &dockercompose.PortsMapping{Host: 80, Container: 80, Protocol: dockercompose.PortProtocolTCP},
+			want:  `"80:80"`,
+		},
+		"range": {
+			input: &dockercompose.PortsMapping{Host: 9000, HostEnd: 9010, Container: 9000, ContainerEnd: 9010},
+			want:  `"9000-9010:9000-9010"`,
+		},
+		"host range to single container port": {
+			input: &dockercompose.PortsMapping{Host: 8000, HostEnd: 8010, Container: 80},
+			want:  `"8000-8010:80"`,
+		},
 	}
 
 	for name, tc := range tests {
@@ -60,3 +91,150 @@ func TestPorts_Render(t *testing.T) {
 		})
 	}
 }
+
+func TestPortsMapping_HostPorts(t *testing.T) {
+	tests := map[string]struct {
+		input *dockercompose.PortsMapping
+		want  []int
+	}{
+		"single":      {input: &dockercompose.PortsMapping{Host: 3306, Container: 3306}, want: []int{3306}},
+		"random host": {input: &dockercompose.PortsMapping{Container: 3306}, want: nil},
+		"range": {
+			input: &dockercompose.PortsMapping{Host: 9000, HostEnd: 9002, Container: 9000, ContainerEnd: 9002},
+			want:  []int{9000, 9001, 9002},
+		},
+		"range of one port": {input: &dockercompose.PortsMapping{Host: 80, HostEnd: 80, Container: 80}, want: []int{80}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := tc.input.HostPorts()
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("HostPorts() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPortsMapping_UnmarshalYAML(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  *dockercompose.PortsMapping
+	}{
+		"container port": {
+			input: `port: "3000"`,
+			want:  &dockercompose.PortsMapping{Container: 3000},
+		},
+		"host and container": {
+			input: `port: "8080:80"`,
+			want:  &dockercompose.PortsMapping{Host: 8080, Container: 80},
+		},
+		"host ip": {
+			input: `port: "127.0.0.1:3307:3306"`,
+			want:  &dockercompose.PortsMapping{HostIP: "127.0.0.1", Host: 3307, Container: 3306},
+		},
+		"host ip with random host port": {
+			input: `port: "127.0.0.1::3306"`,
+			want:  &dockercompose.PortsMapping{HostIP: "127.0.0.1", Container: 3306},
+		},
+		"ipv6 host ip": {
+			input: `port: "[::1]:6001:6001"`,
+			want:  &dockercompose.PortsMapping{HostIP: "::1", Host: 6001, Container: 6001},
+		},
+		"ipv6 host ip with random host port": {
+			input: `port: "[::1]:6001"`,
+			want:  &dockercompose.PortsMapping{HostIP: "::1", Container: 6001},
+		},
+		"range with protocol": {
+			input: `port: "9000-9010:9000-9010/udp"`,
+			want: &dockercompose.PortsMapping{
+				Host:         9000,
+				HostEnd:      9010,
+				Container:    9000,
+				ContainerEnd: 9010,
+				Protocol:     dockercompose.PortProtocolUDP,
+			},
+		},
+		"long syntax": {
+			input: "port:\n  target: 80\n  published: 8080\n  host_ip: 127.0.0.1\n  protocol: tcp\n  mode: host",
+			want: &dockercompose.PortsMapping{
+				HostIP:    "127.0.0.1",
+				Host:      8080,
+				Container: 80,
+				Protocol:  dockercompose.PortProtocolTCP,
+			},
+		},
+		"long syntax with published range": {
+			input: "port:\n  target: 80\n  published: 8000-8010",
+			want:  &dockercompose.PortsMapping{Host: 8000, HostEnd: 8010, Container: 80},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got struct {
+				Port *dockercompose.PortsMapping `yaml:"port"`
+			}
+
+			if err := yaml.Unmarshal([]byte(tc.input), &got); err != nil {
+				t.Fatalf("yaml.Unmarshal() returned error: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got.Port); diff != "" {
+				t.Errorf("PortsMapping.UnmarshalYAML() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExpose_Render(t *testing.T) {
+	tests := map[string]struct {
+		input dockercompose.Expose
+		want  string
+	}{
+		"container ports": {
+			input: dockercompose.Expose{
+				{Host: 3307, Container: 3306},
+				{Container: 8000, ContainerEnd: 8010, Protocol: dockercompose.PortProtocolUDP},
+			},
+			want: `expose:
+  - "3306"
+  - "8000-8010/udp"`,
+		},
+		"empty": {
+			input: dockercompose.Expose{},
+			want:  "",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.input.Render()); diff != "" {
+				t.Errorf("Expose.Render() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExpose_UnmarshalYAML(t *testing.T) {
+	var got struct {
+		Expose dockercompose.Expose `yaml:"expose"`
+	}
+
+	if err := yaml.Unmarshal([]byte(`expose: [3306, "8000-8010/udp"]`), &got); err != nil {
+		t.Fatalf("yaml.Unmarshal() returned error: %s", err)
+	}
+
+	want := dockercompose.Expose{
+		{Container: 3306},
+		{Container: 8000, ContainerEnd: 8010, Protocol: dockercompose.PortProtocolUDP},
+	}
+
+	if diff := cmp.Diff(want, got.Expose); diff != "" {
+		t.Errorf("Expose.UnmarshalYAML() mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := yaml.Unmarshal([]byte(`expose: ["3307:3306"]`), &got); err == nil {
+		t.Errorf("expected error for published port")
+	}
+}
@@ -1,6 +1,6 @@
 package dockercompose
 
-import "fmt"
+import "gopkg.in/yaml.v3"
 
 // RestartPolicy is one of the restart policies supported by docker
 type RestartPolicy string
@@ -15,17 +15,30 @@ const (
 
 // Render formats RestartPolicy as YAML string
 func (r RestartPolicy) Render() string {
-	if r == "" {
-		return ""
-	}
+	return directive("restart", r.node())
+}
 
+func (r RestartPolicy) node() *yaml.Node {
 	if r != RestartPolicyNo && r != RestartPolicyAlways && r != RestartPolicyOnFailure && r != RestartPolicyUnlessStopped {
-		return ""
+		return nil
+	}
+
+	return stringNode(string(r))
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (r *RestartPolicy) UnmarshalYAML(value *yaml.Node) error {
+	var policy string
+
+	if err := value.Decode(&policy); err != nil {
+		return err
 	}
 
-	if r == RestartPolicyNo {
-		return fmt.Sprintf(`restart: "%s"`, string(r))
+	if RestartPolicy(policy).node() == nil {
+		return nodeError(value, "unknown restart policy %q", policy)
 	}
 
-	return fmt.Sprintf("restart: %s", string(r))
+	*r = RestartPolicy(policy)
+
+	return nil
 }
@@ -1,67 +1,155 @@
 package dockercompose
 
-import (
-	"fmt"
-	"strings"
-)
-
-type renderableItem interface {
-	Render() string
-}
+import "gopkg.in/yaml.v3"
 
 // Service represents a single service inside docker-compose services directive
 type Service struct {
-	Name          string
-	Build         *Build
-	Image         *Image
-	ContainerName string
-	WorkingDir    string
-	Restart       RestartPolicy
-	Ports         Ports
-	Environment   Environment
-	Networks      ServiceNetworks
-	Volumes       ServiceVolumes
+	Name            string
+	Build           *Build
+	Image           *Image
+	Command         Command
+	Entrypoint      Entrypoint
+	User            string
+	ContainerName   string
+	WorkingDir      string
+	Restart         RestartPolicy
+	DependsOn       Dependencies
+	Healthcheck     *Healthcheck
+	Ports           Ports
+	Expose          Expose
+	Environment     Environment
+	EnvFile         StringList
+	Networks        ServiceNetworks
+	Volumes         ServiceVolumes
+	Tmpfs           StringList
+	ExtraHosts      ExtraHosts
+	Labels          Labels
+	Ulimits         Ulimits
+	CapAdd          StringList
+	Logging         *Logging
+	StopGracePeriod string
+	Profiles        StringList
 }
 
 // Render formats Service as YAML string
 func (s *Service) Render() string {
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("%s:", s.Name))
+	return directive(s.Name, s.node())
+}
 
-	nesting := NestingLevel(1)
+func (s *Service) node() *yaml.Node {
+	m := mappingNode()
 
 	if s.ContainerName != "" {
-		sb.WriteString("\n")
-		sb.WriteString(nesting.ApplyTo(fmt.Sprintf("container_name: %s", s.ContainerName)))
+		appendPair(m, "container_name", stringNode(s.ContainerName))
 	}
 
 	if s.WorkingDir != "" {
-		sb.WriteString("\n")
-		sb.WriteString(nesting.ApplyTo(fmt.Sprintf("working_dir: %s", s.WorkingDir)))
+		appendPair(m, "working_dir", stringNode(s.WorkingDir))
 	}
 
-	var renderables []renderableItem
-
 	if s.Build != nil {
-		renderables = append(renderables, s.Build)
+		appendPair(m, "build", s.Build.node())
 	}
 
 	if s.Image != nil {
-		renderables = append(renderables, s.Image)
+		appendPair(m, "image", s.Image.node())
 	}
 
-	renderables = append(renderables, s.Restart, s.Ports, s.Environment, s.Networks, s.Volumes)
+	appendPair(m, "command", s.Command.node())
+	appendPair(m, "entrypoint", s.Entrypoint.node())
 
-	for _, r := range renderables {
-		rendered := r.Render()
+	// uid:gid is quoted like ports, so YAML 1.1 parsers do not read it as a base 60 number
+	if s.User != "" {
+		appendPair(m, "user", quotedNode(s.User))
+	}
 
-		if rendered != "" {
-			rendered = nesting.ApplyTo(rendered)
+	appendPair(m, "restart", s.Restart.node())
+	appendPair(m, "depends_on", s.DependsOn.node())
+	appendPair(m, "healthcheck", s.Healthcheck.node())
+	appendPair(m, "ports", s.Ports.node())
+	appendPair(m, "expose", s.Expose.node())
+	appendPair(m, "environment", s.Environment.node())
+	appendPair(m, "env_file", s.EnvFile.node())
+	appendPair(m, "networks", s.Networks.node())
+	appendPair(m, "volumes", s.Volumes.node())
+	appendPair(m, "tmpfs", s.Tmpfs.node())
+	appendPair(m, "extra_hosts", s.ExtraHosts.node())
+	appendPair(m, "labels", s.Labels.node())
+	appendPair(m, "ulimits", s.Ulimits.node())
+	appendPair(m, "cap_add", s.CapAdd.node())
+	appendPair(m, "logging", s.Logging.node())
 
-			sb.WriteString("\n")
-			sb.WriteString(rendered)
-		}
+	if s.StopGracePeriod != "" {
+		appendPair(m, "stop_grace_period", stringNode(s.StopGracePeriod))
 	}
 
-	return sb.String()
+	appendPair(m, "profiles", s.Profiles.node())
+
+	return m
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. Directives which are not modelled by Service are skipped.
+// Name is not a part of the service definition and must be set by the caller
+func (s *Service) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.MappingNode {
+		return nodeError(value, "service must be a mapping")
+	}
+
+	var raw struct {
+		Build           *Build          `yaml:"build"`
+		Image           *Image          `yaml:"image"`
+		Command         Command         `yaml:"command"`
+		Entrypoint      Entrypoint      `yaml:"entrypoint"`
+		User            string          `yaml:"user"`
+		ContainerName   string          `yaml:"container_name"`
+		WorkingDir      string          `yaml:"working_dir"`
+		Restart         RestartPolicy   `yaml:"restart"`
+		DependsOn       Dependencies    `yaml:"depends_on"`
+		Healthcheck     *Healthcheck    `yaml:"healthcheck"`
+		Ports           Ports           `yaml:"ports"`
+		Expose          Expose          `yaml:"expose"`
+		Environment     Environment     `yaml:"environment"`
+		EnvFile         StringList      `yaml:"env_file"`
+		Networks        ServiceNetworks `yaml:"networks"`
+		Volumes         ServiceVolumes  `yaml:"volumes"`
+		Tmpfs           StringList      `yaml:"tmpfs"`
+		ExtraHosts      ExtraHosts      `yaml:"extra_hosts"`
+		Labels          Labels          `yaml:"labels"`
+		Ulimits         Ulimits         `yaml:"ulimits"`
+		CapAdd          StringList      `yaml:"cap_add"`
+		Logging         *Logging        `yaml:"logging"`
+		StopGracePeriod string          `yaml:"stop_grace_period"`
+		Profiles        StringList      `yaml:"profiles"`
+	}
+
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	s.Build = raw.Build
+	s.Image = raw.Image
+	s.Command = raw.Command
+	s.Entrypoint = raw.Entrypoint
+	s.User = raw.User
+	s.ContainerName = raw.ContainerName
+	s.WorkingDir = raw.WorkingDir
+	s.Restart = raw.Restart
+	s.DependsOn = raw.DependsOn
+	s.Healthcheck = raw.Healthcheck
+	s.Ports = raw.Ports
+	s.Expose = raw.Expose
+	s.Environment = raw.Environment
+	s.EnvFile = raw.EnvFile
+	s.Networks = raw.Networks
+	s.Volumes = raw.Volumes
+	s.Tmpfs = raw.Tmpfs
+	s.ExtraHosts = raw.ExtraHosts
+	s.Labels = raw.Labels
+	s.Ulimits = raw.Ulimits
+	s.CapAdd = raw.CapAdd
+	s.Logging = raw.Logging
+	s.StopGracePeriod = raw.StopGracePeriod
+	s.Profiles = raw.Profiles
+
+	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
 
 	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
 )
@@ -19,14 +20,31 @@ func TestService_Render(t *testing.T) {
 			Name: "php",
 			Tag:  "7.4",
 		},
+		Command:       dockercompose.Command{"php-fpm", "-F"},
+		Entrypoint:    dockercompose.Entrypoint{"docker-php-entrypoint"},
+		User:          "1000:1000",
 		ContainerName: "app",
 		WorkingDir:    "/var/www",
 		Restart:       dockercompose.RestartPolicyUnlessStopped,
+		DependsOn:     dockercompose.Dependencies{{Service: "db", Condition: dockercompose.ConditionServiceHealthy}},
+		Healthcheck:   &dockercompose.Healthcheck{Test: []string{"CMD", "php-fpm", "-t"}, Retries: 3},
+		EnvFile:       dockercompose.StringList{".env"},
+		Tmpfs:         dockercompose.StringList{"/tmp"},
+		ExtraHosts:    dockercompose.ExtraHosts{"host.docker.internal": "host-gateway"},
+		Labels:        dockercompose.Labels{"com.example.team": "backend"},
+		Ulimits:       dockercompose.Ulimits{"nofile": {Soft: 20000, Hard: 40000}},
+		CapAdd:        dockercompose.StringList{"SYS_PTRACE"},
+		Logging: &dockercompose.Logging{
+			Driver:  "json-file",
+			Options: map[string]string{"max-size": "10m"},
+		},
+		StopGracePeriod: "1m30s",
+		Profiles:        dockercompose.StringList{"debug"},
 		Environment: dockercompose.Environment{
 			"SERVICE_NAME": "test-service",
 		},
 		Networks: dockercompose.ServiceNetworks{
-			&dockercompose.Network{Name: "test-network", Driver: dockercompose.NetworkDriverBridge},
+			{Network: &dockercompose.Network{Name: "test-network", Driver: dockercompose.NetworkDriverBridge}},
 		},
 		Volumes: dockercompose.ServiceVolumes{
 			&dockercompose.ServiceVolume{Source: "/home/test/app", Target: "/var/www"},
@@ -40,13 +58,43 @@ func TestService_Render(t *testing.T) {
     context: /home/test
     dockerfile: Dockerfile.test
   image: php:7.4
+  command: [php-fpm, -F]
+  entrypoint: [docker-php-entrypoint]
+  user: "1000:1000"
   restart: unless-stopped
+  depends_on:
+    db:
+      condition: service_healthy
+  healthcheck:
+    test: [CMD, php-fpm, -t]
+    retries: 3
   environment:
     SERVICE_NAME: test-service
+  env_file:
+    - .env
   networks:
     - test-network
   volumes:
-    - /home/test/app:/var/www`
+    - /home/test/app:/var/www
+  tmpfs:
+    - /tmp
+  extra_hosts:
+    - host.docker.internal:host-gateway
+  labels:
+    com.example.team: backend
+  ulimits:
+    nofile:
+      soft: 20000
+      hard: 40000
+  cap_add:
+    - SYS_PTRACE
+  logging:
+    driver: json-file
+    options:
+      max-size: 10m
+  stop_grace_period: 1m30s
+  profiles:
+    - debug`
 
 	got := service.Render()
 
@@ -54,3 +102,50 @@ func TestService_Render(t *testing.T) {
 		t.Errorf("service.Render() mismatch (-want +got):\n%s", diff)
 	}
 }
+
+func TestService_UnmarshalYAML(t *testing.T) {
+	input := `entrypoint: /entrypoint.sh
+user: www-data
+env_file: .env
+tmpfs:
+  - /run
+  - /tmp
+extra_hosts:
+  - host.docker.internal:host-gateway
+labels:
+  - com.example.team=backend
+ulimits:
+  nproc: 65535
+cap_add:
+  - NET_ADMIN
+logging:
+  driver: json-file
+stop_grace_period: 30s
+profiles: [debug, tools]
+deploy:
+  replicas: 2`
+
+	var got dockercompose.Service
+
+	if err := yaml.Unmarshal([]byte(input), &got); err != nil {
+		t.Fatalf("yaml.Unmarshal() returned error: %s", err)
+	}
+
+	want := dockercompose.Service{
+		Entrypoint:      dockercompose.Entrypoint{"/entrypoint.sh"},
+		User:            "www-data",
+		EnvFile:         dockercompose.StringList{".env"},
+		Tmpfs:           dockercompose.StringList{"/run", "/tmp"},
+		ExtraHosts:      dockercompose.ExtraHosts{"host.docker.internal": "host-gateway"},
+		Labels:          dockercompose.Labels{"com.example.team": "backend"},
+		Ulimits:         dockercompose.Ulimits{"nproc": {Soft: 65535, Hard: 65535}},
+		CapAdd:          dockercompose.StringList{"NET_ADMIN"},
+		Logging:         &dockercompose.Logging{Driver: "json-file"},
+		StopGracePeriod: "30s",
+		Profiles:        dockercompose.StringList{"debug", "tools"},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Service.UnmarshalYAML() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,89 @@
+package dockercompose
+
+import (
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Ulimit is a soft and hard limit of the resource. Equal limits are rendered as a single number
+type Ulimit struct {
+	Soft int
+	Hard int
+}
+
+func (u *Ulimit) node() *yaml.Node {
+	if u == nil {
+		return nil
+	}
+
+	if u.Soft == u.Hard {
+		return intNode(u.Soft)
+	}
+
+	m := mappingNode()
+	appendPair(m, "soft", intNode(u.Soft))
+	appendPair(m, "hard", intNode(u.Hard))
+
+	return m
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. A single number sets both soft and hard limits
+func (u *Ulimit) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		var limit int
+
+		if err := value.Decode(&limit); err != nil {
+			return err
+		}
+
+		u.Soft, u.Hard = limit, limit
+
+		return nil
+	}
+
+	if err := checkKeys(value, "soft", "hard"); err != nil {
+		return err
+	}
+
+	var raw struct {
+		Soft int `yaml:"soft"`
+		Hard int `yaml:"hard"`
+	}
+
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	u.Soft, u.Hard = raw.Soft, raw.Hard
+
+	return nil
+}
+
+// Ulimits represents 'ulimits' directive. Limits are keyed by resource name (e.g. nofile, nproc)
+type Ulimits map[string]*Ulimit
+
+// Render formats Ulimits as YAML string. Limits are sorted by resource name, so the output is the same across runs
+func (u Ulimits) Render() string {
+	return directive("ulimits", u.node())
+}
+
+func (u Ulimits) node() *yaml.Node {
+	resources := make([]string, 0, len(u))
+
+	for resource := range u {
+		if resource != "" {
+			resources = append(resources, resource)
+		}
+	}
+
+	sort.Strings(resources)
+
+	m := mappingNode()
+
+	for _, resource := range resources {
+		appendPair(m, resource, u[resource].node())
+	}
+
+	return emptyToNil(m)
+}
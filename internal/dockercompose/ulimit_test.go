@@ -0,0 +1,87 @@
+package dockercompose_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
+)
+
+func TestUlimits_Render(t *testing.T) {
+	tests := map[string]struct {
+		input dockercompose.Ulimits
+		want  string
+	}{
+		"single and split limits": {
+			input: dockercompose.Ulimits{
+				"nproc":  {Soft: 65535, Hard: 65535},
+				"nofile": {Soft: 20000, Hard: 40000},
+			},
+			want: `ulimits:
+  nofile:
+    soft: 20000
+    hard: 40000
+  nproc: 65535`,
+		},
+		"empty": {
+			input: nil,
+			want:  "",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.input.Render()); diff != "" {
+				t.Errorf("Ulimits.Render() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUlimits_UnmarshalYAML(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    dockercompose.Ulimits
+		wantErr bool
+	}{
+		"single and split limits": {
+			input: "ulimits:\n  nproc: 65535\n  nofile:\n    soft: 20000\n    hard: 40000",
+			want: dockercompose.Ulimits{
+				"nproc":  {Soft: 65535, Hard: 65535},
+				"nofile": {Soft: 20000, Hard: 40000},
+			},
+		},
+		"unsupported option": {
+			input:   "ulimits:\n  nofile:\n    max: 20000",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got struct {
+				Ulimits dockercompose.Ulimits `yaml:"ulimits"`
+			}
+
+			err := yaml.Unmarshal([]byte(tc.input), &got)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got.Ulimits)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("yaml.Unmarshal() returned error: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got.Ulimits); diff != "" {
+				t.Errorf("Ulimits.UnmarshalYAML() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
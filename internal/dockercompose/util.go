@@ -1,17 +1,14 @@
 package dockercompose
 
 import (
+	"bytes"
 	"fmt"
+	"sort"
 	"strconv"
-)
+	"strings"
 
-func doubleQuotted(str string) string {
-	if str == "" {
-		return ""
-	}
-
-	return strconv.Quote(str)
-}
+	"gopkg.in/yaml.v3"
+)
 
 func mapping(str1, str2 string) string {
 	if str1 == "" {
@@ -24,3 +21,143 @@ func mapping(str1, str2 string) string {
 
 	return fmt.Sprintf("%s:%s", str1, str2)
 }
+
+// stringNode creates a string scalar which is quoted by the encoder whenever the plain form would be read back as
+// something else (e.g. "3.8", "no" or a value containing ": ")
+func stringNode(value string) *yaml.Node {
+	node := &yaml.Node{}
+	_ = node.Encode(value)
+
+	return node
+}
+
+// quotedNode creates a string scalar which is always double-quoted
+func quotedNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Style: yaml.DoubleQuotedStyle, Value: value}
+}
+
+func intNode(value int) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(value)}
+}
+
+func boolNode(value bool) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(value)}
+}
+
+func nullNode() *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null"}
+}
+
+func mappingNode() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode}
+}
+
+func sequenceNode() *yaml.Node {
+	return &yaml.Node{Kind: yaml.SequenceNode}
+}
+
+// appendPair adds key-value pair to the mapping node. Pairs with nil value are skipped
+func appendPair(mapping *yaml.Node, key string, value *yaml.Node) {
+	if value == nil {
+		return
+	}
+
+	mapping.Content = append(mapping.Content, stringNode(key), value)
+}
+
+// appendItem adds item to the sequence node. Nil items are skipped
+func appendItem(sequence *yaml.Node, item *yaml.Node) {
+	if item == nil {
+		return
+	}
+
+	sequence.Content = append(sequence.Content, item)
+}
+
+func emptyToNil(node *yaml.Node) *yaml.Node {
+	if len(node.Content) == 0 {
+		return nil
+	}
+
+	return node
+}
+
+// marshal encodes node as YAML string without trailing newline
+func marshal(node *yaml.Node) string {
+	if node == nil {
+		return ""
+	}
+
+	var buf bytes.Buffer
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(node); err != nil {
+		return ""
+	}
+
+	if err := enc.Close(); err != nil {
+		return ""
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// directive encodes single key-value pair as YAML string
+func directive(key string, value *yaml.Node) string {
+	if value == nil {
+		return ""
+	}
+
+	m := mappingNode()
+	appendPair(m, key, value)
+
+	return marshal(m)
+}
+
+func nodeError(node *yaml.Node, format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", node.Line, fmt.Sprintf(format, args...))
+}
+
+// checkKeys ensures that mapping node has only allowed keys
+func checkKeys(node *yaml.Node, allowed ...string) error {
+	if node.Kind != yaml.MappingNode {
+		return nodeError(node, "expected a mapping")
+	}
+
+	for i := 0; i < len(node.Content); i += 2 {
+		key := node.Content[i]
+
+		if !contains(allowed, key.Value) {
+			return nodeError(key, "unsupported option %q", key.Value)
+		}
+	}
+
+	return nil
+}
+
+// sortedKeys returns non-empty keys of the map in alphabetical order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+
+	return false
+}
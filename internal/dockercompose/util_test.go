@@ -2,31 +2,6 @@ package dockercompose
 
 import "testing"
 
-func TestDoubleQuotted(t *testing.T) {
-	tests := map[string]struct {
-		input string
-		want  string
-	}{
-		"simple": {
-			input: "test",
-			want:  `"test"`,
-		},
-		"empty string": {
-			input: "",
-			want:  "",
-		},
-	}
-
-	for name, tc := range tests {
-		t.Run(name, func(t *testing.T) {
-			got := doubleQuotted(tc.input)
-			if tc.want != got {
-				t.Fatalf("expected: %v, got: %v", tc.want, got)
-			}
-		})
-	}
-}
-
 func TestMapping(t *testing.T) {
 	tests := map[string]struct {
 		str1 string
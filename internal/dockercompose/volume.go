@@ -1,8 +1,9 @@
 package dockercompose
 
 import (
-	"fmt"
 	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 // VolumeDriver is one of the volume drivers supported by docker
@@ -13,19 +14,160 @@ const (
 	VolumeDriverLocal VolumeDriver = "local"
 )
 
-// ServiceVolume represents service-level volume mapping in docker-compose file
+// VolumeType is a type of the mount
+type VolumeType string
+
+// All supported volume types
+const (
+	VolumeTypeBind   VolumeType = "bind"
+	VolumeTypeVolume VolumeType = "volume"
+	VolumeTypeTmpfs  VolumeType = "tmpfs"
+)
+
+// VolumeConsistency is a consistency requirement of the bind mount. It makes a difference only on Docker for Mac
+type VolumeConsistency string
+
+// All supported consistency requirements
+const (
+	VolumeConsistencyConsistent VolumeConsistency = "consistent"
+	VolumeConsistencyCached     VolumeConsistency = "cached"
+	VolumeConsistencyDelegated  VolumeConsistency = "delegated"
+)
+
+// ServiceVolume represents service-level volume mapping in docker-compose file. It is rendered in short syntax
+// ("source:target:mode") unless some of its options can be expressed only in long syntax
 type ServiceVolume struct {
-	Source string
-	Target string
+	// Type is inferred from Source when empty: paths are bind mounts, names are named volumes
+	Type        VolumeType
+	Source      string
+	Target      string
+	ReadOnly    bool
+	Consistency VolumeConsistency
+	// CreateHostPath creates missing source path on the host. Rendered as bind.create_host_path
+	CreateHostPath bool
+	// SELinux is a relabeling option of the bind mount: z (shared) or Z (private)
+	SELinux string
+	// NoCopy disables copying of the data from the container when the volume is created. Rendered as volume.nocopy
+	NoCopy bool
+	// TmpfsSize is a size of the tmpfs mount (e.g. 64m)
+	TmpfsSize string
 }
 
-// String formats ServiceVolume as a mapping
+// EffectiveType returns the type of the mount, inferring it from Source if Type is not set
+func (v *ServiceVolume) EffectiveType() VolumeType {
+	if v.Type != "" {
+		return v.Type
+	}
+
+	if v.Source == "" || !isPath(v.Source) {
+		return VolumeTypeVolume
+	}
+
+	return VolumeTypeBind
+}
+
+// String formats ServiceVolume in short syntax
 func (v *ServiceVolume) String() string {
 	if v.Target == "" {
 		return ""
 	}
 
-	return mapping(v.Source, v.Target)
+	short := mapping(v.Source, v.Target)
+
+	if modes := v.modes(); len(modes) != 0 {
+		short += ":" + strings.Join(modes, ",")
+	}
+
+	return short
+}
+
+func (v *ServiceVolume) modes() []string {
+	var modes []string
+
+	if v.ReadOnly {
+		modes = append(modes, "ro")
+	}
+
+	if v.Consistency != "" {
+		modes = append(modes, string(v.Consistency))
+	}
+
+	if v.SELinux != "" {
+		modes = append(modes, v.SELinux)
+	}
+
+	return modes
+}
+
+// hasShortSyntax checks if all options of the volume can be expressed in short syntax
+func (v *ServiceVolume) hasShortSyntax() bool {
+	if v.Type == VolumeTypeTmpfs || v.CreateHostPath || v.NoCopy || v.TmpfsSize != "" {
+		return false
+	}
+
+	if v.Type != "" && v.Type != (&ServiceVolume{Source: v.Source}).EffectiveType() {
+		return false
+	}
+
+	// "target:ro" would be read back as a mapping of "target" to "ro"
+	return v.Source != "" || len(v.modes()) == 0
+}
+
+func (v *ServiceVolume) node() *yaml.Node {
+	if v.Target == "" {
+		return nil
+	}
+
+	if v.hasShortSyntax() {
+		return stringNode(v.String())
+	}
+
+	m := mappingNode()
+	appendPair(m, "type", stringNode(string(v.EffectiveType())))
+
+	if v.Source != "" {
+		appendPair(m, "source", stringNode(v.Source))
+	}
+
+	appendPair(m, "target", stringNode(v.Target))
+
+	if v.ReadOnly {
+		appendPair(m, "read_only", boolNode(true))
+	}
+
+	if v.Consistency != "" {
+		appendPair(m, "consistency", stringNode(string(v.Consistency)))
+	}
+
+	bind := mappingNode()
+
+	if v.CreateHostPath {
+		appendPair(bind, "create_host_path", boolNode(true))
+	}
+
+	if v.SELinux != "" {
+		appendPair(bind, "selinux", stringNode(v.SELinux))
+	}
+
+	appendPair(m, "bind", emptyToNil(bind))
+
+	if v.NoCopy {
+		volume := mappingNode()
+		appendPair(volume, "nocopy", boolNode(true))
+		appendPair(m, "volume", volume)
+	}
+
+	if v.TmpfsSize != "" {
+		tmpfs := mappingNode()
+		appendPair(tmpfs, "size", stringNode(v.TmpfsSize))
+		appendPair(m, "tmpfs", tmpfs)
+	}
+
+	return m
+}
+
+func isPath(source string) bool {
+	return strings.HasPrefix(source, "/") || strings.HasPrefix(source, ".") || strings.HasPrefix(source, "~")
 }
 
 // ServiceVolumes represents service-level volumes directive
@@ -33,49 +175,71 @@ type ServiceVolumes []*ServiceVolume
 
 // Render formats ServiceVolumes as YAML string
 func (v ServiceVolumes) Render() string {
-	length := len(v)
-
-	if length == 0 {
-		return ""
-	}
-
-	var sb strings.Builder
-	sb.WriteString("volumes:\n")
+	return directive("volumes", v.node())
+}
 
-	for i, volume := range v {
-		sb.WriteString(fmt.Sprintf("  - %s", volume))
+func (v ServiceVolumes) node() *yaml.Node {
+	seq := sequenceNode()
 
-		if i+1 != length {
-			sb.WriteString("\n")
-		}
+	for _, volume := range v {
+		appendItem(seq, volume.node())
 	}
 
-	return sb.String()
+	return emptyToNil(seq)
 }
 
 // NamedVolume represents top-level volume in docker-compose file
 type NamedVolume struct {
 	Name   string
 	Driver VolumeDriver
+	// DriverOpts are passed to the driver when the volume is created (e.g. type, device and o of the local driver)
+	DriverOpts map[string]string
+	Labels     Labels
+	// External volume is created outside of the compose project, so only its name is rendered
+	External bool
 }
 
 // Render formats NamedVolume as YAML string
 func (v *NamedVolume) Render() string {
-	if v.Name == "" || v.Driver == "" {
-		return ""
+	return directive(v.Name, v.node())
+}
+
+// Local driver is the default one, so it is rendered only as an empty volume
+func (v *NamedVolume) node() *yaml.Node {
+	if v.Name == "" {
+		return nil
+	}
+
+	m := mappingNode()
+
+	if v.External {
+		appendPair(m, "external", boolNode(true))
+
+		return m
+	}
+
+	if v.Driver == "" && len(v.DriverOpts) == 0 && len(v.Labels) == 0 {
+		return nil
+	}
+
+	if v.Driver != "" && v.Driver != VolumeDriverLocal {
+		appendPair(m, "driver", stringNode(string(v.Driver)))
 	}
 
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("%s:", v.Name))
+	opts := mappingNode()
 
-	if v.Driver == VolumeDriverLocal {
-		return sb.String()
+	for _, opt := range sortedKeys(v.DriverOpts) {
+		appendPair(opts, opt, stringNode(v.DriverOpts[opt]))
 	}
 
-	sb.WriteString("\n")
-	sb.WriteString(fmt.Sprintf("  driver: %s", v.Driver))
+	appendPair(m, "driver_opts", emptyToNil(opts))
+	appendPair(m, "labels", v.Labels.node())
 
-	return sb.String()
+	if len(m.Content) == 0 {
+		return nullNode()
+	}
+
+	return m
 }
 
 // ToServiceVolume transforms NamedVolume to ServiceVolume
@@ -92,15 +256,17 @@ type NamedVolumes []*NamedVolume
 
 // Render formats NamedVolumes as YAML string
 func (v NamedVolumes) Render() string {
-	var sb strings.Builder
+	return marshal(v.node())
+}
 
-	if len(v) != 0 {
-		for _, vol := range v {
-			sb.WriteString(vol.Render())
-		}
+func (v NamedVolumes) node() *yaml.Node {
+	m := mappingNode()
+
+	for _, vol := range v {
+		appendPair(m, vol.Name, vol.node())
 	}
 
-	return sb.String()
+	return emptyToNil(m)
 }
 
 // IsEmpty checks if NamedVolumes has zero volumes
@@ -122,3 +288,195 @@ func (v NamedVolumes) ToServiceVolumes() ServiceVolumes {
 
 	return vols
 }
+
+// UnmarshalYAML implements yaml.Unmarshaler. Both short ("source:target:mode") and long syntax are supported
+func (v *ServiceVolume) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		parts := strings.Split(value.Value, ":")
+
+		switch len(parts) {
+		case 1:
+			v.Target = parts[0]
+		case 2:
+			v.Source, v.Target = parts[0], parts[1]
+		case 3:
+			v.Source, v.Target = parts[0], parts[1]
+
+			return v.applyModes(value, parts[2])
+		default:
+			return nodeError(value, "unsupported volume mapping %q", value.Value)
+		}
+
+		return nil
+	}
+
+	if err := checkKeys(value, "type", "source", "target", "read_only", "consistency", "bind", "volume", "tmpfs"); err != nil {
+		return err
+	}
+
+	var raw struct {
+		Type        VolumeType        `yaml:"type"`
+		Source      string            `yaml:"source"`
+		Target      string            `yaml:"target"`
+		ReadOnly    bool              `yaml:"read_only"`
+		Consistency VolumeConsistency `yaml:"consistency"`
+		Bind        yaml.Node         `yaml:"bind"`
+		Volume      yaml.Node         `yaml:"volume"`
+		Tmpfs       yaml.Node         `yaml:"tmpfs"`
+	}
+
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	if raw.Target == "" {
+		return nodeError(value, "volume target is required")
+	}
+
+	switch raw.Type {
+	case "", VolumeTypeBind, VolumeTypeVolume, VolumeTypeTmpfs:
+	default:
+		return nodeError(value, "unsupported volume type %q", raw.Type)
+	}
+
+	switch raw.Consistency {
+	case "", VolumeConsistencyConsistent, VolumeConsistencyCached, VolumeConsistencyDelegated:
+	default:
+		return nodeError(value, "unsupported volume consistency %q", raw.Consistency)
+	}
+
+	v.Type = raw.Type
+	v.Source = raw.Source
+	v.Target = raw.Target
+	v.ReadOnly = raw.ReadOnly
+	v.Consistency = raw.Consistency
+
+	if raw.Bind.Kind != 0 {
+		if err := checkKeys(&raw.Bind, "create_host_path", "selinux"); err != nil {
+			return err
+		}
+
+		var bind struct {
+			CreateHostPath bool   `yaml:"create_host_path"`
+			SELinux        string `yaml:"selinux"`
+		}
+
+		if err := raw.Bind.Decode(&bind); err != nil {
+			return err
+		}
+
+		v.CreateHostPath = bind.CreateHostPath
+		v.SELinux = bind.SELinux
+	}
+
+	if raw.Volume.Kind != 0 {
+		if err := checkKeys(&raw.Volume, "nocopy"); err != nil {
+			return err
+		}
+
+		var volume struct {
+			NoCopy bool `yaml:"nocopy"`
+		}
+
+		if err := raw.Volume.Decode(&volume); err != nil {
+			return err
+		}
+
+		v.NoCopy = volume.NoCopy
+	}
+
+	if raw.Tmpfs.Kind != 0 {
+		if err := checkKeys(&raw.Tmpfs, "size"); err != nil {
+			return err
+		}
+
+		var tmpfs struct {
+			Size string `yaml:"size"`
+		}
+
+		if err := raw.Tmpfs.Decode(&tmpfs); err != nil {
+			return err
+		}
+
+		v.TmpfsSize = tmpfs.Size
+	}
+
+	return nil
+}
+
+func (v *ServiceVolume) applyModes(value *yaml.Node, modes string) error {
+	for _, mode := range strings.Split(modes, ",") {
+		switch mode {
+		case "ro":
+			v.ReadOnly = true
+		case "rw":
+			v.ReadOnly = false
+		case string(VolumeConsistencyConsistent), string(VolumeConsistencyCached), string(VolumeConsistencyDelegated):
+			v.Consistency = VolumeConsistency(mode)
+		case "z", "Z":
+			v.SELinux = mode
+		case "nocopy":
+			v.NoCopy = true
+		default:
+			return nodeError(value, "unsupported volume mode %q", mode)
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. Driver is left untouched when it is not specified
+func (v *NamedVolume) UnmarshalYAML(value *yaml.Node) error {
+	if err := checkKeys(value, "driver", "driver_opts", "labels", "external"); err != nil {
+		return err
+	}
+
+	var raw struct {
+		Driver     VolumeDriver      `yaml:"driver"`
+		DriverOpts map[string]string `yaml:"driver_opts"`
+		Labels     Labels            `yaml:"labels"`
+		External   bool              `yaml:"external"`
+	}
+
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	if raw.Driver != "" {
+		v.Driver = raw.Driver
+	}
+
+	if raw.External {
+		v.External = true
+		v.Driver = ""
+	}
+
+	v.DriverOpts = raw.DriverOpts
+	v.Labels = raw.Labels
+
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. Volumes are kept in the order of declaration and use local driver
+// unless specified otherwise
+func (v *NamedVolumes) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.MappingNode {
+		return nodeError(value, "volumes must be a mapping")
+	}
+
+	volumes := NamedVolumes{}
+
+	for i := 0; i < len(value.Content); i += 2 {
+		volume := &NamedVolume{Name: value.Content[i].Value, Driver: VolumeDriverLocal}
+
+		if err := value.Content[i+1].Decode(volume); err != nil {
+			return err
+		}
+
+		volumes = append(volumes, volume)
+	}
+
+	*v = volumes
+
+	return nil
+}
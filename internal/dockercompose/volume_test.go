@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
 
 	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
 )
@@ -34,6 +35,26 @@ func TestNamedVolume_String(t *testing.T) {
 			input: dockercompose.NamedVolume{},
 			want:  "",
 		},
+		"with driver options and labels": {
+			input: dockercompose.NamedVolume{
+				Name:       "composer-cache",
+				Driver:     dockercompose.VolumeDriverLocal,
+				DriverOpts: map[string]string{"type": "tmpfs", "device": "tmpfs", "o": "size=100m"},
+				Labels:     dockercompose.Labels{"com.example.app": "awesome-app"},
+			},
+			want: `composer-cache:
+  driver_opts:
+    device: tmpfs
+    o: size=100m
+    type: tmpfs
+  labels:
+    com.example.app: awesome-app`,
+		},
+		"external": {
+			input: dockercompose.NamedVolume{Name: "shared-data", Driver: "foo", External: true},
+			want: `shared-data:
+  external: true`,
+		},
 	}
 
 	for name, tc := range tests {
@@ -67,6 +88,20 @@ func TestVolume_String(t *testing.T) {
 			input: dockercompose.ServiceVolume{},
 			want:  "",
 		},
+		"read-only": {
+			input: dockercompose.ServiceVolume{Source: "./nginx.conf", Target: "/etc/nginx/nginx.conf", ReadOnly: true},
+			want:  "./nginx.conf:/etc/nginx/nginx.conf:ro",
+		},
+		"with consistency and selinux label": {
+			input: dockercompose.ServiceVolume{
+				Source:      "/home/test",
+				Target:      "/var/test",
+				ReadOnly:    true,
+				Consistency: dockercompose.VolumeConsistencyCached,
+				SELinux:     "z",
+			},
+			want: "/home/test:/var/test:ro,cached,z",
+		},
 	}
 
 	for name, tc := range tests {
@@ -92,6 +127,47 @@ func TestVolumes_Render(t *testing.T) {
 			want: `volumes:
   - /home/test:/var/test
   - /var/test`},
+		"short syntax with modes": {
+			input: dockercompose.ServiceVolumes{
+				{Source: "/home/test", Target: "/var/www", Consistency: dockercompose.VolumeConsistencyDelegated},
+				{Type: dockercompose.VolumeTypeVolume, Source: "app-data", Target: "/var/lib/mysql"},
+				{Type: dockercompose.VolumeTypeBind, Source: "./app.conf", Target: "/etc/app.conf", ReadOnly: true},
+			},
+			want: `volumes:
+  - /home/test:/var/www:delegated
+  - app-data:/var/lib/mysql
+  - ./app.conf:/etc/app.conf:ro`},
+		"long syntax": {
+			input: dockercompose.ServiceVolumes{
+				{Source: "/home/test/storage", Target: "/var/www/storage", CreateHostPath: true, SELinux: "Z"},
+				{Source: "app-data", Target: "/var/lib/app", NoCopy: true, ReadOnly: true},
+				{Type: dockercompose.VolumeTypeTmpfs, Target: "/tmp", TmpfsSize: "64m"},
+				{Target: "/var/cache", ReadOnly: true},
+				{Type: dockercompose.VolumeTypeBind, Source: "shared", Target: "/shared"},
+			},
+			want: `volumes:
+  - type: bind
+    source: /home/test/storage
+    target: /var/www/storage
+    bind:
+      create_host_path: true
+      selinux: Z
+  - type: volume
+    source: app-data
+    target: /var/lib/app
+    read_only: true
+    volume:
+      nocopy: true
+  - type: tmpfs
+    target: /tmp
+    tmpfs:
+      size: 64m
+  - type: volume
+    target: /var/cache
+    read_only: true
+  - type: bind
+    source: shared
+    target: /shared`},
 		"empty": {
 			input: dockercompose.ServiceVolumes{},
 			want:  "",
@@ -108,6 +184,42 @@ func TestVolumes_Render(t *testing.T) {
 	}
 }
 
+func TestNamedVolumes_UnmarshalYAML(t *testing.T) {
+	input := `app-data:
+composer-cache:
+  driver_opts:
+    type: tmpfs
+    device: tmpfs
+  labels:
+    - com.example.app=awesome-app
+shared-data:
+  external: true
+custom-data:
+  driver: foo`
+
+	want := dockercompose.NamedVolumes{
+		{Name: "app-data", Driver: dockercompose.VolumeDriverLocal},
+		{
+			Name:       "composer-cache",
+			Driver:     dockercompose.VolumeDriverLocal,
+			DriverOpts: map[string]string{"type": "tmpfs", "device": "tmpfs"},
+			Labels:     dockercompose.Labels{"com.example.app": "awesome-app"},
+		},
+		{Name: "shared-data", External: true},
+		{Name: "custom-data", Driver: "foo"},
+	}
+
+	var got dockercompose.NamedVolumes
+
+	if err := yaml.Unmarshal([]byte(input), &got); err != nil {
+		t.Fatalf("unmarshal: %s", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("NamedVolumes.UnmarshalYAML() mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestNamedVolume_ToServiceVolume(t *testing.T) {
 	tests := map[string]struct {
 		input *dockercompose.NamedVolume
@@ -177,3 +289,97 @@ func TestNamedVolumes_ToServiceVolumes(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceVolume_EffectiveType(t *testing.T) {
+	tests := map[string]struct {
+		input dockercompose.ServiceVolume
+		want  dockercompose.VolumeType
+	}{
+		"absolute path":    {input: dockercompose.ServiceVolume{Source: "/home/test"}, want: dockercompose.VolumeTypeBind},
+		"relative path":    {input: dockercompose.ServiceVolume{Source: "./conf"}, want: dockercompose.VolumeTypeBind},
+		"home path":        {input: dockercompose.ServiceVolume{Source: "~/conf"}, want: dockercompose.VolumeTypeBind},
+		"named volume":     {input: dockercompose.ServiceVolume{Source: "app-data"}, want: dockercompose.VolumeTypeVolume},
+		"anonymous volume": {input: dockercompose.ServiceVolume{}, want: dockercompose.VolumeTypeVolume},
+		"explicit type": {
+			input: dockercompose.ServiceVolume{Type: dockercompose.VolumeTypeTmpfs},
+			want:  dockercompose.VolumeTypeTmpfs,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.input.EffectiveType(); got != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestServiceVolume_UnmarshalYAML(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  *dockercompose.ServiceVolume
+	}{
+		"short syntax with modes": {
+			input: "volume: ./conf:/etc/conf:ro,delegated,z",
+			want: &dockercompose.ServiceVolume{
+				Source:      "./conf",
+				Target:      "/etc/conf",
+				ReadOnly:    true,
+				Consistency: dockercompose.VolumeConsistencyDelegated,
+				SELinux:     "z",
+			},
+		},
+		"short syntax with nocopy": {
+			input: "volume: app-data:/var/lib/app:nocopy",
+			want:  &dockercompose.ServiceVolume{Source: "app-data", Target: "/var/lib/app", NoCopy: true},
+		},
+		"long syntax bind": {
+			input: `volume:
+  type: bind
+  source: ./storage
+  target: /var/www/storage
+  read_only: true
+  consistency: cached
+  bind:
+    create_host_path: true`,
+			want: &dockercompose.ServiceVolume{
+				Type:           dockercompose.VolumeTypeBind,
+				Source:         "./storage",
+				Target:         "/var/www/storage",
+				ReadOnly:       true,
+				Consistency:    dockercompose.VolumeConsistencyCached,
+				CreateHostPath: true,
+			},
+		},
+		"long syntax volume": {
+			input: "volume:\n  type: volume\n  source: app-data\n  target: /var/lib/app\n  volume:\n    nocopy: true",
+			want: &dockercompose.ServiceVolume{
+				Type:   dockercompose.VolumeTypeVolume,
+				Source: "app-data",
+				Target: "/var/lib/app",
+				NoCopy: true,
+			},
+		},
+		"long syntax tmpfs": {
+			input: "volume:\n  type: tmpfs\n  target: /tmp\n  tmpfs:\n    size: 64m",
+			want:  &dockercompose.ServiceVolume{Type: dockercompose.VolumeTypeTmpfs, Target: "/tmp", TmpfsSize: "64m"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got struct {
+				Volume *dockercompose.ServiceVolume `yaml:"volume"`
+			}
+
+			if err := yaml.Unmarshal([]byte(tc.input), &got); err != nil {
+				t.Fatalf("yaml.Unmarshal() returned error: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got.Volume); diff != "" {
+				t.Errorf("ServiceVolume.UnmarshalYAML() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -2,7 +2,6 @@ package assemble
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
 	"github.com/Bocmah/phpdocker-gen/pkg/service"
@@ -14,24 +13,46 @@ func DockerCompose(conf *service.FullConfig) *dockercompose.Config {
 		Version: "3.8",
 	}
 
-	appName := formatAppName(conf.AppName)
+	appName := conf.FormattedAppName()
 
-	if conf.Services.PresentServicesCount() > 1 {
+	if len(conf.Networks) != 0 {
+		compose.Networks = createNetworks(conf.Networks)
+	} else if conf.Services.PresentServicesCount() > 1 {
 		compose.Networks = dockercompose.Networks{createDefaultNetwork(appName)}
 	}
 
-	if conf.Services.IsPresent(service.Database) {
-		compose.Volumes = dockercompose.NamedVolumes{createDefaultVolume(appName)}
-	}
-
 	optsAssembler := &optionsAssembler{
 		compose:      compose,
+		projectRoot:  conf.ProjectRoot,
+		outputPath:   conf.GetOutputPath(),
 		serviceFiles: conf.GetServiceFiles(),
 		serviceEnv:   conf.GetEnvironment(),
+		networks:     conf.Networks,
+		volumes:      conf.Volumes,
+		dataVolumes:  map[service.SupportedService]dockercompose.ServiceVolumes{},
+		dbVolumes:    map[string]dockercompose.ServiceVolumes{},
+	}
+
+	for _, v := range conf.DataVolumes() {
+		compose.Volumes = append(compose.Volumes, &dockercompose.NamedVolume{
+			Name:   v.Name,
+			Driver: dockercompose.VolumeDriverLocal,
+		})
+
+		mounts := dockercompose.ServiceVolumes{&dockercompose.ServiceVolume{Source: v.Name, Target: v.Target}}
+
+		switch v.Service {
+		case service.Redis.ServiceName():
+			optsAssembler.dataVolumes[service.Redis] = mounts
+		case service.MongoDB.ServiceName():
+			optsAssembler.dataVolumes[service.MongoDB] = mounts
+		default:
+			optsAssembler.dbVolumes[v.Service] = mounts
+		}
 	}
 
-	if conf.Services.IsPresent(service.Database) {
-		optsAssembler.databaseSystemInUse = conf.Services.Database.System
+	for _, v := range conf.Volumes {
+		compose.Volumes = append(compose.Volumes, createVolume(v))
 	}
 
 	for _, s := range service.SupportedServices() {
@@ -39,16 +60,24 @@ func DockerCompose(conf *service.FullConfig) *dockercompose.Config {
 			continue
 		}
 
+		if s == service.Database {
+			for _, db := range conf.Services.AllDatabases() {
+				assembler := NewDatabaseAssembler(db)
+
+				compose.Services = append(compose.Services, assembler(conf, optsAssembler.assembleForDatabase(db)...))
+			}
+
+			continue
+		}
+
 		assembler := NewServiceAssembler(s)
 
 		compose.Services = append(compose.Services, assembler(conf, optsAssembler.assembleForService(s)...))
 	}
 
-	return compose
-}
+	linkDependencies(compose, conf)
 
-func formatAppName(name string) string {
-	return strings.ReplaceAll(strings.ToLower(name), " ", "-")
+	return compose
 }
 
 func createDefaultNetwork(appName string) *dockercompose.Network {
@@ -58,9 +87,36 @@ func createDefaultNetwork(appName string) *dockercompose.Network {
 	}
 }
 
-func createDefaultVolume(appName string) *dockercompose.NamedVolume {
+// createNetworks creates networks declared in the config in the order of declaration
+func createNetworks(networks []*service.NetworkConfig) dockercompose.Networks {
+	created := make(dockercompose.Networks, 0, len(networks))
+
+	for _, n := range networks {
+		network := &dockercompose.Network{
+			Name:     n.Name,
+			Driver:   dockercompose.NetworkDriver(n.Driver),
+			External: n.External,
+		}
+
+		if n.Subnet != "" {
+			network.IPAM = &dockercompose.IPAM{
+				Config: []*dockercompose.IPAMPool{{Subnet: n.Subnet, Gateway: n.Gateway, IPRange: n.IPRange}},
+			}
+		}
+
+		created = append(created, network)
+	}
+
+	return created
+}
+
+// createVolume creates named volume declared in the config
+func createVolume(v *service.VolumeConfig) *dockercompose.NamedVolume {
 	return &dockercompose.NamedVolume{
-		Name:   fmt.Sprintf("%s-data", appName),
-		Driver: dockercompose.VolumeDriverLocal,
+		Name:       v.Name,
+		Driver:     dockercompose.VolumeDriver(v.Driver),
+		DriverOpts: v.DriverOpts,
+		Labels:     v.Labels,
+		External:   v.External,
 	}
 }
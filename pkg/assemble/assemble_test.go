@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/Bocmah/phpdocker-gen/pkg/assemble"
+	"github.com/Bocmah/phpdocker-gen/pkg/service"
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
@@ -23,24 +24,39 @@ func TestDockerCompose(t *testing.T) {
 				ContainerName: "test-app",
 				WorkingDir:    "/var/www",
 				Restart:       dockercompose.RestartPolicyUnlessStopped,
+				DependsOn: dockercompose.Dependencies{
+					{Service: "db", Condition: dockercompose.ConditionServiceHealthy},
+				},
+				Healthcheck: &dockercompose.Healthcheck{
+					Test: []string{
+						"CMD-SHELL",
+						"SCRIPT_NAME=/ping SCRIPT_FILENAME=/ping REQUEST_METHOD=GET cgi-fcgi -bind -connect 127.0.0.1:9000 || exit 1",
+					},
+					Interval: "10s",
+					Timeout:  "5s",
+					Retries:  5,
+				},
 				Volumes: dockercompose.ServiceVolumes{
 					{Source: "/home/test/app", Target: "/var/www"},
 				},
-				Networks: dockercompose.ServiceNetworks{network},
+				Networks: dockercompose.ServiceNetworks{{Network: network}},
 			},
 			{
 				Name:          "webserver",
 				Image:         &dockercompose.Image{Name: "nginx", Tag: "alpine"},
 				ContainerName: "webserver",
 				Restart:       dockercompose.RestartPolicyUnlessStopped,
+				DependsOn: dockercompose.Dependencies{
+					{Service: "php-fpm", Condition: dockercompose.ConditionServiceHealthy},
+				},
 				Ports: dockercompose.Ports{
 					{Host: 80, Container: 80},
 					{Host: 443, Container: 443},
 				},
-				Networks: dockercompose.ServiceNetworks{network},
+				Networks: dockercompose.ServiceNetworks{{Network: network}},
 				Volumes: dockercompose.ServiceVolumes{
 					{Source: "/home/test/app", Target: "/var/www"},
-					{Source: "/home/test/app/.docker/nginx/conf.d/app.conf", Target: "/etc/nginx/conf.d/app.conf"},
+					{Source: "/home/test/app/.docker/nginx/conf.d/app.conf", Target: "/etc/nginx/conf.d/app.conf", ReadOnly: true},
 				},
 			},
 			{
@@ -48,6 +64,12 @@ func TestDockerCompose(t *testing.T) {
 				Image:         &dockercompose.Image{Name: "mysql", Tag: "8.0"},
 				ContainerName: "db",
 				Restart:       dockercompose.RestartPolicyUnlessStopped,
+				Healthcheck: &dockercompose.Healthcheck{
+					Test:     []string{"CMD", "mysqladmin", "ping", "-h", "127.0.0.1", "-P", "3306"},
+					Interval: "10s",
+					Timeout:  "5s",
+					Retries:  5,
+				},
 				Ports: dockercompose.Ports{
 					{Host: 3306, Container: 3306},
 				},
@@ -60,13 +82,13 @@ func TestDockerCompose(t *testing.T) {
 				Volumes: dockercompose.ServiceVolumes{
 					{Source: "test-app-data", Target: "/var/lib/mysql"},
 				},
-				Networks: dockercompose.ServiceNetworks{network},
+				Networks: dockercompose.ServiceNetworks{{Network: network}},
 			},
 			{
 				Name:          "nodejs",
 				Build:         &dockercompose.Build{Context: "/home/test/app", Dockerfile: "/home/test/app/.docker/nodejs/Dockerfile"},
 				ContainerName: "nodejs",
-				Networks:      dockercompose.ServiceNetworks{network},
+				Networks:      dockercompose.ServiceNetworks{{Network: network}},
 				Volumes: dockercompose.ServiceVolumes{
 					{Source: "/home/test/app", Target: "/opt"},
 				},
@@ -87,3 +109,457 @@ func TestDockerCompose(t *testing.T) {
 		t.Fatalf("DockerCompose mismatch (-want +got):\n%s", diff)
 	}
 }
+
+func TestDockerCompose_RedisVolume(t *testing.T) {
+	tests := map[string]struct {
+		persistence bool
+		wantVolumes dockercompose.NamedVolumes
+		wantRedis   dockercompose.ServiceVolumes
+	}{
+		"without persistence": {
+			wantVolumes: dockercompose.NamedVolumes{
+				&dockercompose.NamedVolume{Name: "test-app-data", Driver: dockercompose.VolumeDriverLocal},
+			},
+		},
+		"with persistence": {
+			persistence: true,
+			wantVolumes: dockercompose.NamedVolumes{
+				&dockercompose.NamedVolume{Name: "test-app-data", Driver: dockercompose.VolumeDriverLocal},
+				&dockercompose.NamedVolume{Name: "test-app-redis-data", Driver: dockercompose.VolumeDriverLocal},
+			},
+			wantRedis: dockercompose.ServiceVolumes{
+				&dockercompose.ServiceVolume{Source: "test-app-redis-data", Target: "/data"},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			conf := dummyConf()
+			conf.Services.Redis = &service.RedisConfig{Version: "6.0", Port: 6379, Persistence: tc.persistence}
+
+			got := assemble.DockerCompose(conf)
+
+			if diff := cmp.Diff(tc.wantVolumes, got.Volumes); diff != "" {
+				t.Errorf("named volumes mismatch (-want +got):\n%s", diff)
+			}
+
+			for _, s := range got.Services {
+				switch s.Name {
+				case "redis":
+					if diff := cmp.Diff(tc.wantRedis, s.Volumes); diff != "" {
+						t.Errorf("redis volumes mismatch (-want +got):\n%s", diff)
+					}
+				case "db":
+					want := dockercompose.ServiceVolumes{
+						&dockercompose.ServiceVolume{Source: "test-app-data", Target: "/var/lib/mysql"},
+					}
+
+					if diff := cmp.Diff(want, s.Volumes); diff != "" {
+						t.Errorf("db volumes mismatch (-want +got):\n%s", diff)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDockerCompose_MongoDBVolume(t *testing.T) {
+	conf := dummyConf()
+	conf.Services.MongoDB = &service.MongoDBConfig{Version: "4.4", Port: 27017}
+
+	got := assemble.DockerCompose(conf)
+
+	wantVolumes := dockercompose.NamedVolumes{
+		&dockercompose.NamedVolume{Name: "test-app-data", Driver: dockercompose.VolumeDriverLocal},
+		&dockercompose.NamedVolume{Name: "test-app-mongodb-data", Driver: dockercompose.VolumeDriverLocal},
+	}
+
+	if diff := cmp.Diff(wantVolumes, got.Volumes); diff != "" {
+		t.Errorf("named volumes mismatch (-want +got):\n%s", diff)
+	}
+
+	for _, s := range got.Services {
+		if s.Name != "mongodb" {
+			continue
+		}
+
+		want := dockercompose.ServiceVolumes{
+			&dockercompose.ServiceVolume{Source: "test-app-mongodb-data", Target: "/data/db"},
+		}
+
+		if diff := cmp.Diff(want, s.Volumes); diff != "" {
+			t.Errorf("mongodb volumes mismatch (-want +got):\n%s", diff)
+		}
+
+		return
+	}
+
+	t.Errorf("mongodb service was not assembled")
+}
+
+func TestDockerCompose_MultipleDatabases(t *testing.T) {
+	conf := dummyConf()
+	conf.Services.Database = nil
+	conf.Services.Databases = []*service.DatabaseConfig{
+		{
+			System:      service.MySQL,
+			Version:     "8.0",
+			Port:        3306,
+			Credentials: service.Credentials{RootPassword: "secret-root"},
+		},
+		{
+			Service:     "analytics",
+			System:      service.PostgreSQL,
+			Version:     "12.3",
+			Port:        5432,
+			Credentials: service.Credentials{Password: "secret"},
+		},
+	}
+
+	got := assemble.DockerCompose(conf)
+
+	wantVolumes := dockercompose.NamedVolumes{
+		&dockercompose.NamedVolume{Name: "test-app-data", Driver: dockercompose.VolumeDriverLocal},
+		&dockercompose.NamedVolume{Name: "test-app-analytics-data", Driver: dockercompose.VolumeDriverLocal},
+	}
+
+	if diff := cmp.Diff(wantVolumes, got.Volumes); diff != "" {
+		t.Errorf("named volumes mismatch (-want +got):\n%s", diff)
+	}
+
+	network := dockercompose.ServiceNetworks{
+		{Network: &dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge}},
+	}
+
+	want := []*dockercompose.Service{
+		{
+			Name:          "db",
+			Image:         &dockercompose.Image{Name: "mysql", Tag: "8.0"},
+			ContainerName: "db",
+			Restart:       dockercompose.RestartPolicyUnlessStopped,
+			Healthcheck: &dockercompose.Healthcheck{
+				Test:     []string{"CMD", "mysqladmin", "ping", "-h", "127.0.0.1", "-P", "3306"},
+				Interval: "10s",
+				Timeout:  "5s",
+				Retries:  5,
+			},
+			Ports:       dockercompose.Ports{&dockercompose.PortsMapping{Host: 3306, Container: 3306}},
+			Environment: dockercompose.Environment{"MYSQL_ROOT_PASSWORD": "secret-root"},
+			Networks:    network,
+			Volumes: dockercompose.ServiceVolumes{
+				&dockercompose.ServiceVolume{Source: "test-app-data", Target: "/var/lib/mysql"},
+			},
+		},
+		{
+			Name:          "analytics",
+			Image:         &dockercompose.Image{Name: "postgres", Tag: "12.3"},
+			ContainerName: "analytics",
+			Restart:       dockercompose.RestartPolicyUnlessStopped,
+			Healthcheck: &dockercompose.Healthcheck{
+				Test:     []string{"CMD", "pg_isready", "-h", "127.0.0.1", "-p", "5432", "-U", "postgres"},
+				Interval: "10s",
+				Timeout:  "5s",
+				Retries:  5,
+			},
+			Ports:       dockercompose.Ports{&dockercompose.PortsMapping{Host: 5432, Container: 5432}},
+			Environment: dockercompose.Environment{"POSTGRES_PASSWORD": "secret"},
+			Networks:    network,
+			Volumes: dockercompose.ServiceVolumes{
+				&dockercompose.ServiceVolume{Source: "test-app-analytics-data", Target: "/var/lib/postgresql/data"},
+			},
+		},
+	}
+
+	var databases []*dockercompose.Service
+
+	for _, s := range got.Services {
+		if s.Image != nil && (s.Image.Name == "mysql" || s.Image.Name == "postgres") {
+			databases = append(databases, s)
+		}
+	}
+
+	if diff := cmp.Diff(want, databases); diff != "" {
+		t.Errorf("database services mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDockerCompose_InitScripts(t *testing.T) {
+	conf := dummyConf()
+	conf.Services.Database.InitScripts = []string{"database/schema.sql", "/home/test/app/database/seed.sh"}
+
+	got := assemble.DockerCompose(conf)
+
+	want := dockercompose.ServiceVolumes{
+		&dockercompose.ServiceVolume{Source: "test-app-data", Target: "/var/lib/mysql"},
+		&dockercompose.ServiceVolume{Source: "/home/test/app/database/schema.sql", Target: "/docker-entrypoint-initdb.d/schema.sql", ReadOnly: true},
+		&dockercompose.ServiceVolume{Source: "/home/test/app/database/seed.sh", Target: "/docker-entrypoint-initdb.d/seed.sh", ReadOnly: true},
+	}
+
+	for _, s := range got.Services {
+		if s.Name != "db" {
+			continue
+		}
+
+		if diff := cmp.Diff(want, s.Volumes); diff != "" {
+			t.Errorf("db volumes mismatch (-want +got):\n%s", diff)
+		}
+
+		return
+	}
+
+	t.Errorf("db service was not assembled")
+}
+
+func TestDockerCompose_DatabaseSettings(t *testing.T) {
+	conf := dummyConf()
+	conf.Services.Database = nil
+	conf.Services.Databases = []*service.DatabaseConfig{
+		{
+			System:   service.MySQL,
+			Version:  "8.0",
+			Port:     3306,
+			Settings: map[string]string{"max_connections": "200"},
+		},
+		{
+			Service:  "analytics",
+			System:   service.PostgreSQL,
+			Version:  "12.3",
+			Port:     5432,
+			Settings: map[string]string{"max_connections": "200", "search_path": "'$user', public"},
+		},
+	}
+
+	got := assemble.DockerCompose(conf)
+
+	tests := map[string]struct {
+		wantCommand dockercompose.Command
+		wantVolumes dockercompose.ServiceVolumes
+	}{
+		"db": {
+			wantVolumes: dockercompose.ServiceVolumes{
+				&dockercompose.ServiceVolume{Source: "test-app-data", Target: "/var/lib/mysql"},
+				&dockercompose.ServiceVolume{Source: "/home/test/app/.docker/db/my.cnf", Target: "/etc/mysql/conf.d/app.cnf", ReadOnly: true},
+			},
+		},
+		"analytics": {
+			wantCommand: dockercompose.Command{"postgres", "-c", "max_connections=200", "-c", "search_path='$user', public"},
+			wantVolumes: dockercompose.ServiceVolumes{
+				&dockercompose.ServiceVolume{Source: "test-app-analytics-data", Target: "/var/lib/postgresql/data"},
+			},
+		},
+	}
+
+	for _, s := range got.Services {
+		tc, ok := tests[s.Name]
+
+		if !ok {
+			continue
+		}
+
+		if diff := cmp.Diff(tc.wantCommand, s.Command); diff != "" {
+			t.Errorf("%s command mismatch (-want +got):\n%s", s.Name, diff)
+		}
+
+		if diff := cmp.Diff(tc.wantVolumes, s.Volumes); diff != "" {
+			t.Errorf("%s volumes mismatch (-want +got):\n%s", s.Name, diff)
+		}
+
+		delete(tests, s.Name)
+	}
+
+	for name := range tests {
+		t.Errorf("%s service was not assembled", name)
+	}
+}
+
+func TestDockerCompose_Dependencies(t *testing.T) {
+	conf := dummyConf()
+	conf.Services.Redis = &service.RedisConfig{Version: "6.0", Port: 6379}
+	conf.Services.MongoDB = &service.MongoDBConfig{Version: "4.4", Port: 27017}
+
+	got := assemble.DockerCompose(conf)
+
+	tests := map[string]dockercompose.Dependencies{
+		"php-fpm": {
+			{Service: "db", Condition: dockercompose.ConditionServiceHealthy},
+			{Service: "redis", Condition: dockercompose.ConditionServiceHealthy},
+			{Service: "mongodb", Condition: dockercompose.ConditionServiceHealthy},
+		},
+		"webserver": {
+			{Service: "php-fpm", Condition: dockercompose.ConditionServiceHealthy},
+		},
+		"db":      nil,
+		"nodejs":  nil,
+		"redis":   nil,
+		"mongodb": nil,
+	}
+
+	for _, s := range got.Services {
+		want, ok := tests[s.Name]
+
+		if !ok {
+			t.Errorf("unexpected service %s", s.Name)
+			continue
+		}
+
+		if diff := cmp.Diff(want, s.DependsOn); diff != "" {
+			t.Errorf("%s dependencies mismatch (-want +got):\n%s", s.Name, diff)
+		}
+	}
+}
+
+func TestDockerCompose_DeclaredNetworks(t *testing.T) {
+	conf := dummyConf()
+	conf.Networks = []*service.NetworkConfig{
+		{Name: "backend", Driver: "bridge", Subnet: "172.28.0.0/16", Gateway: "172.28.0.1"},
+		{
+			Name:     "proxy",
+			External: true,
+			Services: map[string]*service.NetworkMemberConfig{
+				"webserver": {Aliases: []string{"test-server.test"}},
+			},
+		},
+	}
+
+	got := assemble.DockerCompose(conf)
+
+	backend := &dockercompose.Network{
+		Name:   "backend",
+		Driver: dockercompose.NetworkDriverBridge,
+		IPAM: &dockercompose.IPAM{
+			Config: []*dockercompose.IPAMPool{{Subnet: "172.28.0.0/16", Gateway: "172.28.0.1"}},
+		},
+	}
+	proxy := &dockercompose.Network{Name: "proxy", External: true}
+
+	if diff := cmp.Diff(dockercompose.Networks{backend, proxy}, got.Networks); diff != "" {
+		t.Errorf("networks mismatch (-want +got):\n%s", diff)
+	}
+
+	tests := map[string]dockercompose.ServiceNetworks{
+		"php-fpm": {{Network: backend}},
+		"webserver": {
+			{Network: backend},
+			{Network: proxy, Aliases: []string{"test-server.test"}},
+		},
+		"db":     {{Network: backend}},
+		"nodejs": {{Network: backend}},
+	}
+
+	for _, s := range got.Services {
+		want, ok := tests[s.Name]
+
+		if !ok {
+			t.Errorf("unexpected service %s", s.Name)
+			continue
+		}
+
+		if diff := cmp.Diff(want, s.Networks); diff != "" {
+			t.Errorf("%s networks mismatch (-want +got):\n%s", s.Name, diff)
+		}
+	}
+}
+
+func TestDockerCompose_DeclaredVolumes(t *testing.T) {
+	conf := dummyConf()
+	conf.Volumes = []*service.VolumeConfig{
+		{
+			Name:       "node-modules",
+			Driver:     "local",
+			DriverOpts: map[string]string{"type": "tmpfs", "device": "tmpfs"},
+			Services: map[string]*service.VolumeMountConfig{
+				"nodejs":  {Target: "/opt/node_modules"},
+				"php-fpm": {Target: "/var/www/node_modules", ReadOnly: true},
+			},
+		},
+		{
+			Name:     "shared-assets",
+			External: true,
+			Services: map[string]*service.VolumeMountConfig{
+				"webserver": {Target: "/usr/share/nginx/assets", ReadOnly: true},
+				"db":        {Target: "/assets"},
+			},
+		},
+	}
+
+	got := assemble.DockerCompose(conf)
+
+	wantVolumes := dockercompose.NamedVolumes{
+		{Name: "test-app-data", Driver: dockercompose.VolumeDriverLocal},
+		{
+			Name:       "node-modules",
+			Driver:     dockercompose.VolumeDriverLocal,
+			DriverOpts: map[string]string{"type": "tmpfs", "device": "tmpfs"},
+		},
+		{Name: "shared-assets", External: true},
+	}
+
+	if diff := cmp.Diff(wantVolumes, got.Volumes); diff != "" {
+		t.Errorf("named volumes mismatch (-want +got):\n%s", diff)
+	}
+
+	tests := map[string]dockercompose.ServiceVolumes{
+		"php-fpm": {
+			{Source: "/home/test/app", Target: "/var/www"},
+			{Source: "node-modules", Target: "/var/www/node_modules", ReadOnly: true},
+		},
+		"nodejs": {
+			{Source: "/home/test/app", Target: "/opt"},
+			{Source: "node-modules", Target: "/opt/node_modules"},
+		},
+		"webserver": {
+			{Source: "/home/test/app", Target: "/var/www"},
+			{Source: "/home/test/app/.docker/nginx/conf.d/app.conf", Target: "/etc/nginx/conf.d/app.conf", ReadOnly: true},
+			{Source: "shared-assets", Target: "/usr/share/nginx/assets", ReadOnly: true},
+		},
+		"db": {
+			{Source: "test-app-data", Target: "/var/lib/mysql"},
+			{Source: "shared-assets", Target: "/assets"},
+		},
+	}
+
+	for _, s := range got.Services {
+		want, ok := tests[s.Name]
+
+		if !ok {
+			t.Errorf("unexpected service %s", s.Name)
+			continue
+		}
+
+		if diff := cmp.Diff(want, s.Volumes); diff != "" {
+			t.Errorf("%s volumes mismatch (-want +got):\n%s", s.Name, diff)
+		}
+	}
+}
+
+func TestDockerCompose_ProjectRootConsistency(t *testing.T) {
+	conf := dummyConf()
+	conf.ProjectRootConsistency = "cached"
+
+	got := assemble.DockerCompose(conf)
+
+	want := map[string]*dockercompose.ServiceVolume{
+		"php-fpm":   {Source: "/home/test/app", Target: "/var/www", Consistency: dockercompose.VolumeConsistencyCached},
+		"webserver": {Source: "/home/test/app", Target: "/var/www", Consistency: dockercompose.VolumeConsistencyCached},
+		"nodejs":    {Source: "/home/test/app", Target: "/opt", Consistency: dockercompose.VolumeConsistencyCached},
+	}
+
+	for _, s := range got.Services {
+		wantVolume, ok := want[s.Name]
+
+		if !ok {
+			continue
+		}
+
+		if diff := cmp.Diff(wantVolume, s.Volumes[0]); diff != "" {
+			t.Errorf("%s project root volume mismatch (-want +got):\n%s", s.Name, diff)
+		}
+
+		delete(want, s.Name)
+	}
+
+	for name := range want {
+		t.Errorf("service %s was not assembled", name)
+	}
+}
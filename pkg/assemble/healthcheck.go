@@ -0,0 +1,109 @@
+package assemble
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
+	"github.com/Bocmah/phpdocker-gen/pkg/service"
+)
+
+// mongoDBPingCommand pings MongoDB with mongosh, falling back to the legacy mongo shell which is the only one shipped
+// with images before 5.0
+const mongoDBPingCommand = "mongosh --quiet --eval 'db.runCommand({ping: 1})' || mongo --quiet --eval 'db.runCommand({ping: 1})'"
+
+func newHealthcheck(test ...string) *dockercompose.Healthcheck {
+	return &dockercompose.Healthcheck{
+		Test:     test,
+		Interval: "10s",
+		Timeout:  "5s",
+		Retries:  5,
+	}
+}
+
+// databaseHealthcheck pings the database over TCP. Connecting through the socket would succeed as soon as the temporary
+// server which runs init scripts is up
+func databaseHealthcheck(db *service.DatabaseConfig) *dockercompose.Healthcheck {
+	port := strconv.Itoa(db.TargetPort())
+
+	switch db.System {
+	case service.MySQL:
+		return newHealthcheck("CMD", "mysqladmin", "ping", "-h", "127.0.0.1", "-P", port)
+	case service.MariaDB:
+		return newHealthcheck("CMD", "mariadb-admin", "ping", "-h", "127.0.0.1", "-P", port)
+	case service.PostgreSQL:
+		user := db.Username
+
+		if user == "" {
+			user = "postgres"
+		}
+
+		return newHealthcheck("CMD", "pg_isready", "-h", "127.0.0.1", "-p", port, "-U", user)
+	default:
+		return nil
+	}
+}
+
+// phpFPMHealthcheck queries php-fpm ping page over FastCGI on the port nginx passes requests to
+func phpFPMHealthcheck(conf *service.FullConfig) *dockercompose.Healthcheck {
+	port := service.PHPFPMPort
+
+	if nginx := conf.Services.Nginx; nginx != nil && nginx.FastCGI != nil && nginx.FastCGI.PassPort != 0 {
+		port = nginx.FastCGI.PassPort
+	}
+
+	return newHealthcheck(
+		"CMD-SHELL",
+		fmt.Sprintf("SCRIPT_NAME=/ping SCRIPT_FILENAME=/ping REQUEST_METHOD=GET cgi-fcgi -bind -connect 127.0.0.1:%d || exit 1", port),
+	)
+}
+
+func redisHealthcheck(redis *service.RedisConfig) *dockercompose.Healthcheck {
+	if redis.Password != "" {
+		return newHealthcheck("CMD", "redis-cli", "--no-auth-warning", "-a", redis.Password, "ping")
+	}
+
+	return newHealthcheck("CMD", "redis-cli", "ping")
+}
+
+func mongoDBHealthcheck() *dockercompose.Healthcheck {
+	return newHealthcheck("CMD-SHELL", mongoDBPingCommand)
+}
+
+// linkDependencies makes services wait for the services they connect to. A dependency with a healthcheck has to
+// become healthy, the rest only have to be started
+func linkDependencies(compose *dockercompose.Config, conf *service.FullConfig) {
+	services := map[string]*dockercompose.Service{}
+
+	for _, s := range compose.Services {
+		services[s.Name] = s
+	}
+
+	for name, dependencies := range conf.Services.Dependencies() {
+		dependsOn(services, name, dependencies...)
+	}
+}
+
+func dependsOn(services map[string]*dockercompose.Service, name string, dependencies ...string) {
+	s, ok := services[name]
+
+	if !ok {
+		return
+	}
+
+	for _, d := range dependencies {
+		dependency, ok := services[d]
+
+		if !ok {
+			continue
+		}
+
+		condition := dockercompose.ConditionServiceStarted
+
+		if dependency.Healthcheck != nil {
+			condition = dockercompose.ConditionServiceHealthy
+		}
+
+		s.DependsOn = append(s.DependsOn, &dockercompose.Dependency{Service: d, Condition: condition})
+	}
+}
@@ -18,41 +18,123 @@ type Option interface {
 }
 
 type optionsAssembler struct {
-	databaseSystemInUse service.SupportedSystem
-	compose             *dockercompose.Config
-	serviceFiles        service.Files
-	serviceEnv          service.Environment
+	compose      *dockercompose.Config
+	projectRoot  string
+	outputPath   string
+	serviceFiles service.Files
+	serviceEnv   service.Environment
+	// dataVolumes are named volumes which retain data of the service across container lifecycles
+	dataVolumes map[service.SupportedService]dockercompose.ServiceVolumes
+	// dbVolumes are data volumes of databases keyed by database service name
+	dbVolumes map[string]dockercompose.ServiceVolumes
+	// networks are declared in the config. They are created in the same order as top-level networks of compose
+	networks []*service.NetworkConfig
+	// volumes are declared in the config and mounted to the services listed in them
+	volumes []*service.VolumeConfig
 }
 
 func (o *optionsAssembler) assembleForService(serv service.SupportedService) []Option {
 	var opts []Option
 
-	if len(o.compose.Volumes) != 0 && serv == service.Database {
-		databaseVols := o.getVolumesForDatabase()
-
-		if len(databaseVols) != 0 {
-			opts = append(opts, WithVolumes(databaseVols))
-		}
+	if vols := o.dataVolumes[serv]; len(vols) != 0 {
+		opts = append(opts, WithVolumes(vols))
 	}
 
-	if len(o.compose.Networks) != 0 {
-		opts = append(opts, WithNetworks(o.compose.Networks.ToServiceNetworks()))
+	if networks := o.serviceNetworks(serv.ServiceName()); len(networks) != 0 {
+		opts = append(opts, WithNetworks(networks))
 	}
 
 	opts = append(opts, o.serviceFileOpts(serv)...)
 	opts = append(opts, o.serviceEnvOpts(serv)...)
 
+	if vols := o.mountedVolumes(serv.ServiceName()); len(vols) != 0 {
+		opts = append(opts, WithVolumes(vols))
+	}
+
 	return opts
 }
 
-func (o *optionsAssembler) getVolumesForDatabase() dockercompose.ServiceVolumes {
-	vols := dockercompose.ServiceVolumes{}
+func (o *optionsAssembler) assembleForDatabase(db *service.DatabaseConfig) []Option {
+	var opts []Option
+
+	volumes := append(dockercompose.ServiceVolumes{}, o.dbVolumes[db.ServiceName()]...)
+
+	if file := db.ConfigFile(o.outputPath); file != nil && file.IsMountable() {
+		volumes = append(volumes, configFileVolume(file))
+	}
+
+	targets := db.InitScriptsInContainer()
+
+	for i, script := range db.InitScriptsOnHost(o.projectRoot) {
+		volumes = append(volumes, &dockercompose.ServiceVolume{
+			Source:   script,
+			Target:   targets[i],
+			ReadOnly: true,
+		})
+	}
+
+	volumes = append(volumes, o.mountedVolumes(db.ServiceName())...)
+
+	if len(volumes) != 0 {
+		opts = append(opts, WithVolumes(volumes))
+	}
 
-	for _, vol := range o.compose.Volumes {
-		vols = append(vols, &dockercompose.ServiceVolume{Source: vol.Name, Target: o.databaseSystemInUse.DataPath()})
+	if networks := o.serviceNetworks(db.ServiceName()); len(networks) != 0 {
+		opts = append(opts, WithNetworks(networks))
 	}
 
-	return vols
+	if env := db.Environment(); len(env) != 0 {
+		opts = append(opts, WithEnvironment(env))
+	}
+
+	return opts
+}
+
+// serviceNetworks returns networks the docker-compose service is attached to. Without declared networks services are
+// attached to all networks of compose
+func (o *optionsAssembler) serviceNetworks(name string) dockercompose.ServiceNetworks {
+	if len(o.networks) == 0 {
+		return o.compose.Networks.ToServiceNetworks()
+	}
+
+	var networks dockercompose.ServiceNetworks
+
+	for i, network := range o.networks {
+		member, ok := network.Member(name)
+
+		if !ok {
+			continue
+		}
+
+		networks = append(networks, &dockercompose.ServiceNetwork{
+			Network:     o.compose.Networks[i],
+			Aliases:     member.Aliases,
+			IPv4Address: member.IPv4Address,
+		})
+	}
+
+	return networks
+}
+
+// mountedVolumes returns volumes declared in the config which are mounted to the docker-compose service
+func (o *optionsAssembler) mountedVolumes(name string) dockercompose.ServiceVolumes {
+	var volumes dockercompose.ServiceVolumes
+
+	for _, v := range o.volumes {
+		mount, ok := v.Services[name]
+
+		if !ok || mount == nil {
+			continue
+		}
+
+		volumes = append(volumes, &dockercompose.ServiceVolume{
+			Source:   v.Name,
+			Target:   mount.Target,
+			ReadOnly: mount.ReadOnly,
+		})
+	}
+
+	return volumes
 }
 
 func (o *optionsAssembler) serviceFileOpts(serv service.SupportedService) []Option {
@@ -71,7 +153,7 @@ func (o *optionsAssembler) serviceFileOpts(serv service.SupportedService) []Opti
 		}
 
 		if file.IsMountable() {
-			volumes = append(volumes, &dockercompose.ServiceVolume{Source: file.PathOnHost, Target: file.PathInContainer})
+			volumes = append(volumes, configFileVolume(file))
 		}
 	}
 
@@ -92,6 +174,21 @@ func (o *optionsAssembler) serviceEnvOpts(serv service.SupportedService) []Optio
 	return []Option{WithEnvironment(env)}
 }
 
+// configFileVolume mounts generated file into the container. The file is read-only, so changes made inside the
+// container do not end up in the generated config
+func configFileVolume(file *service.File) *dockercompose.ServiceVolume {
+	return &dockercompose.ServiceVolume{Source: file.PathOnHost, Target: file.PathInContainer, ReadOnly: true}
+}
+
+// projectRootVolume mounts the project root into the container at target
+func projectRootVolume(conf *service.FullConfig, target string) *dockercompose.ServiceVolume {
+	return &dockercompose.ServiceVolume{
+		Source:      conf.ProjectRoot,
+		Target:      target,
+		Consistency: dockercompose.VolumeConsistency(conf.ProjectRootConsistency),
+	}
+}
+
 type dockerfilePathOption string
 
 func (dp dockerfilePathOption) apply(opts *options) {
@@ -134,7 +231,7 @@ type volumesOption struct {
 }
 
 func (v volumesOption) apply(opts *options) {
-	opts.volumes = v.Volumes
+	opts.volumes = append(opts.volumes, v.Volumes...)
 }
 
 // WithVolumes adds volumes to options
@@ -26,7 +26,7 @@ func newTestDockerComposeConfig() *dockercompose.Config {
 				Volumes: dockercompose.ServiceVolumes{
 					{Source: "/home/test/app", Target: "/var/www"},
 				},
-				Networks: dockercompose.ServiceNetworks{network},
+				Networks: dockercompose.ServiceNetworks{{Network: network}},
 			},
 			{
 				Name:          "webserver",
@@ -37,10 +37,10 @@ func newTestDockerComposeConfig() *dockercompose.Config {
 					{Host: 80, Container: 80},
 					{Host: 443, Container: 443},
 				},
-				Networks: dockercompose.ServiceNetworks{network},
+				Networks: dockercompose.ServiceNetworks{{Network: network}},
 				Volumes: dockercompose.ServiceVolumes{
 					{Source: "/home/test/app", Target: "/var/www"},
-					{Source: "/home/test/app/.docker/nginx/conf.d/app.conf", Target: "/etc/nginx/conf.d/app.conf"},
+					{Source: "/home/test/app/.docker/nginx/conf.d/app.conf", Target: "/etc/nginx/conf.d/app.conf", ReadOnly: true},
 				},
 			},
 			{
@@ -60,13 +60,13 @@ func newTestDockerComposeConfig() *dockercompose.Config {
 				Volumes: dockercompose.ServiceVolumes{
 					{Source: "test-app-data", Target: "/var/lib/mysql"},
 				},
-				Networks: dockercompose.ServiceNetworks{network},
+				Networks: dockercompose.ServiceNetworks{{Network: network}},
 			},
 			{
 				Name:          "nodejs",
 				Build:         &dockercompose.Build{Context: "/home/test/app", Dockerfile: "/home/test/app/.docker/nodejs/Dockerfile"},
 				ContainerName: "nodejs",
-				Networks:      dockercompose.ServiceNetworks{network},
+				Networks:      dockercompose.ServiceNetworks{{Network: network}},
 				Volumes: dockercompose.ServiceVolumes{
 					{Source: "/home/test/app", Target: "/opt"},
 				},
@@ -133,7 +133,7 @@ func TestOptionsAssembler(t *testing.T) {
 			input: service.PHP,
 			want: []Option{
 				networksOption{
-					Networks: dockercompose.ServiceNetworks{&dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge}},
+					Networks: dockercompose.ServiceNetworks{{Network: &dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge}}},
 				},
 				dockerfilePathOption("/home/test/app/.docker/php/Dockerfile"),
 			},
@@ -142,10 +142,10 @@ func TestOptionsAssembler(t *testing.T) {
 			input: service.Nginx,
 			want: []Option{
 				networksOption{
-					Networks: dockercompose.ServiceNetworks{&dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge}},
+					Networks: dockercompose.ServiceNetworks{{Network: &dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge}}},
 				},
 				volumesOption{
-					Volumes: dockercompose.ServiceVolumes{&dockercompose.ServiceVolume{Source: "/home/test/app/.docker/nginx/conf.d/app.conf", Target: "/etc/nginx/conf.d/app.conf"}},
+					Volumes: dockercompose.ServiceVolumes{&dockercompose.ServiceVolume{Source: "/home/test/app/.docker/nginx/conf.d/app.conf", Target: "/etc/nginx/conf.d/app.conf", ReadOnly: true}},
 				},
 			},
 		},
@@ -153,7 +153,7 @@ func TestOptionsAssembler(t *testing.T) {
 			input: service.NodeJS,
 			want: []Option{
 				networksOption{
-					Networks: dockercompose.ServiceNetworks{&dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge}},
+					Networks: dockercompose.ServiceNetworks{{Network: &dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge}}},
 				},
 				dockerfilePathOption("/home/test/app/.docker/nodejs/Dockerfile"),
 			},
@@ -165,7 +165,7 @@ func TestOptionsAssembler(t *testing.T) {
 					Volumes: dockercompose.ServiceVolumes{&dockercompose.ServiceVolume{Source: "test-app-data", Target: "/var/lib/mysql"}},
 				},
 				networksOption{
-					Networks: dockercompose.ServiceNetworks{&dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge}},
+					Networks: dockercompose.ServiceNetworks{{Network: &dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge}}},
 				},
 				environmentOption{
 					Environment: dockercompose.Environment{
@@ -179,7 +179,11 @@ func TestOptionsAssembler(t *testing.T) {
 		},
 	}
 
-	optsAssembler := &optionsAssembler{compose: compose, serviceFiles: serviceFiles, serviceEnv: serviceEnv, databaseSystemInUse: service.MySQL}
+	dataVolumes := map[service.SupportedService]dockercompose.ServiceVolumes{
+		service.Database: {&dockercompose.ServiceVolume{Source: "test-app-data", Target: "/var/lib/mysql"}},
+	}
+
+	optsAssembler := &optionsAssembler{compose: compose, serviceFiles: serviceFiles, serviceEnv: serviceEnv, dataVolumes: dataVolumes}
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
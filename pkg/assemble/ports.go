@@ -0,0 +1,143 @@
+package assemble
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"syscall"
+
+	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
+	"github.com/Bocmah/phpdocker-gen/pkg/service"
+)
+
+// HostPort is a single port of the host which is published by a service
+type HostPort struct {
+	Service  string
+	HostIP   string
+	Port     int
+	Protocol dockercompose.PortProtocol
+}
+
+func (p *HostPort) String() string {
+	port := strconv.Itoa(p.Port)
+
+	if p.HostIP != "" {
+		port = net.JoinHostPort(p.HostIP, port)
+	}
+
+	if p.Protocol != dockercompose.PortProtocolTCP {
+		port += "/" + string(p.Protocol)
+	}
+
+	return port
+}
+
+// collidesWith checks if both ports can not be published at the same time. Port published on all interfaces collides
+// with the same port published on any interface
+func (p *HostPort) collidesWith(other *HostPort) bool {
+	if p.Port != other.Port || p.Protocol != other.Protocol {
+		return false
+	}
+
+	if isAnyAddress(p.HostIP) || isAnyAddress(other.HostIP) || p.HostIP == other.HostIP {
+		return true
+	}
+
+	return net.ParseIP(p.HostIP).Equal(net.ParseIP(other.HostIP))
+}
+
+func isAnyAddress(ip string) bool {
+	return ip == "" || net.ParseIP(ip).IsUnspecified()
+}
+
+// HostPorts returns host ports published by services of the compose config in the order of declaration. Ranges are
+// expanded to single ports and ports published on a random host port are skipped
+func HostPorts(compose *dockercompose.Config) []*HostPort {
+	var ports []*HostPort
+
+	for _, s := range compose.Services {
+		for _, m := range s.Ports {
+			protocol := m.Protocol
+
+			if protocol == "" {
+				protocol = dockercompose.PortProtocolTCP
+			}
+
+			for _, port := range m.HostPorts() {
+				ports = append(ports, &HostPort{Service: s.Name, HostIP: m.HostIP, Port: port, Protocol: protocol})
+			}
+		}
+	}
+
+	return ports
+}
+
+// CheckHostPorts reports host ports which are published more than once, because docker-compose fails to start
+// the second container in that case
+func CheckHostPorts(compose *dockercompose.Config) error {
+	errs := &service.ValidationErrors{}
+	ports := HostPorts(compose)
+
+	for i, port := range ports {
+		for _, previous := range ports[:i] {
+			if !previous.collidesWith(port) {
+				continue
+			}
+
+			if previous.Service == port.Service {
+				errs.Add(fmt.Sprintf("Host port %s is published more than once by %s", port, port.Service))
+			} else {
+				errs.Add(fmt.Sprintf("Host port %s is published by both %s and %s", port, previous.Service, port.Service))
+			}
+
+			break
+		}
+	}
+
+	if errs.IsEmpty() {
+		return nil
+	}
+
+	return errs
+}
+
+// CheckLocalPorts reports host ports which are already taken by listeners on the local machine. A port is considered
+// taken only when binding to it fails because the address is in use, so ports which require privileges are not reported
+func CheckLocalPorts(compose *dockercompose.Config) error {
+	errs := &service.ValidationErrors{}
+
+	for _, port := range HostPorts(compose) {
+		if isInUse(port) {
+			errs.Add(fmt.Sprintf("Host port %s of %s is already in use", port, port.Service))
+		}
+	}
+
+	if errs.IsEmpty() {
+		return nil
+	}
+
+	return errs
+}
+
+func isInUse(port *HostPort) bool {
+	address := net.JoinHostPort(port.HostIP, strconv.Itoa(port.Port))
+
+	var err error
+
+	if port.Protocol == dockercompose.PortProtocolUDP {
+		var conn net.PacketConn
+
+		if conn, err = net.ListenPacket("udp", address); err == nil {
+			_ = conn.Close()
+		}
+	} else {
+		var listener net.Listener
+
+		if listener, err = net.Listen("tcp", address); err == nil {
+			_ = listener.Close()
+		}
+	}
+
+	return errors.Is(err, syscall.EADDRINUSE)
+}
@@ -0,0 +1,209 @@
+package assemble_test
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
+	"github.com/Bocmah/phpdocker-gen/pkg/assemble"
+	"github.com/Bocmah/phpdocker-gen/pkg/service"
+)
+
+func composeWithPorts(ports map[string]dockercompose.Ports) *dockercompose.Config {
+	compose := &dockercompose.Config{Version: "3.8"}
+
+	for _, name := range []string{"webserver", "db", "redis"} {
+		if p, ok := ports[name]; ok {
+			compose.Services = append(compose.Services, &dockercompose.Service{Name: name, Ports: p})
+		}
+	}
+
+	return compose
+}
+
+func TestHostPorts(t *testing.T) {
+	compose := composeWithPorts(map[string]dockercompose.Ports{
+		"webserver": {
+			{Host: 80, Container: 80},
+			{Host: 8000, HostEnd: 8001, Container: 8000, ContainerEnd: 8001},
+		},
+		"db": {
+			{HostIP: "127.0.0.1", Host: 3306, Container: 3306},
+			{Container: 33060},
+		},
+		"redis": {
+			{Host: 6379, Container: 6379, Protocol: dockercompose.PortProtocolUDP},
+		},
+	})
+
+	want := []*assemble.HostPort{
+		{Service: "webserver", Port: 80, Protocol: dockercompose.PortProtocolTCP},
+		{Service: "webserver", Port: 8000, Protocol: dockercompose.PortProtocolTCP},
+		{Service: "webserver", Port: 8001, Protocol: dockercompose.PortProtocolTCP},
+		{Service: "db", HostIP: "127.0.0.1", Port: 3306, Protocol: dockercompose.PortProtocolTCP},
+		{Service: "redis", Port: 6379, Protocol: dockercompose.PortProtocolUDP},
+	}
+
+	if diff := cmp.Diff(want, assemble.HostPorts(compose)); diff != "" {
+		t.Errorf("HostPorts() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestHostPort_String(t *testing.T) {
+	tests := map[string]struct {
+		input *assemble.HostPort
+		want  string
+	}{
+		"tcp":     {input: &assemble.HostPort{Port: 80, Protocol: dockercompose.PortProtocolTCP}, want: "80"},
+		"udp":     {input: &assemble.HostPort{Port: 53, Protocol: dockercompose.PortProtocolUDP}, want: "53/udp"},
+		"host ip": {input: &assemble.HostPort{HostIP: "127.0.0.1", Port: 80, Protocol: dockercompose.PortProtocolTCP}, want: "127.0.0.1:80"},
+		"ipv6":    {input: &assemble.HostPort{HostIP: "::1", Port: 80, Protocol: dockercompose.PortProtocolTCP}, want: "[::1]:80"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.input.String(); got != tc.want {
+				t.Errorf("expected: %s, got: %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCheckHostPorts(t *testing.T) {
+	tests := map[string]struct {
+		ports map[string]dockercompose.Ports
+		want  service.ValidationErrors
+	}{
+		"no collisions": {
+			ports: map[string]dockercompose.Ports{
+				"webserver": {{Host: 80, Container: 80}, {Host: 443, Container: 443}},
+				"db":        {{Host: 3306, Container: 3306}},
+			},
+		},
+		"same port": {
+			ports: map[string]dockercompose.Ports{
+				"webserver": {{Host: 3306, Container: 80}},
+				"db":        {{Host: 3306, Container: 3306}},
+			},
+			want: service.ValidationErrors{"Host port 3306 is published by both webserver and db"},
+		},
+		"same port within service": {
+			ports: map[string]dockercompose.Ports{
+				"webserver": {{Host: 80, Container: 80}, {Host: 80, Container: 443}},
+			},
+			want: service.ValidationErrors{"Host port 80 is published more than once by webserver"},
+		},
+		"port inside range": {
+			ports: map[string]dockercompose.Ports{
+				"webserver": {{Host: 6370, HostEnd: 6380, Container: 6370, ContainerEnd: 6380}},
+				"redis":     {{Host: 6379, Container: 6379}},
+			},
+			want: service.ValidationErrors{"Host port 6379 is published by both webserver and redis"},
+		},
+		"different protocols": {
+			ports: map[string]dockercompose.Ports{
+				"webserver": {{Host: 5353, Container: 53}},
+				"redis":     {{Host: 5353, Container: 53, Protocol: dockercompose.PortProtocolUDP}},
+			},
+		},
+		"different host ips": {
+			ports: map[string]dockercompose.Ports{
+				"webserver": {{HostIP: "127.0.0.1", Host: 3306, Container: 3306}},
+				"db":        {{HostIP: "192.168.0.10", Host: 3306, Container: 3306}},
+			},
+		},
+		"host ip and all interfaces": {
+			ports: map[string]dockercompose.Ports{
+				"webserver": {{Host: 3306, Container: 3306}},
+				"db":        {{HostIP: "127.0.0.1", Host: 3306, Container: 3306}},
+			},
+			want: service.ValidationErrors{"Host port 127.0.0.1:3306 is published by both webserver and db"},
+		},
+		"unspecified host ip": {
+			ports: map[string]dockercompose.Ports{
+				"webserver": {{HostIP: "0.0.0.0", Host: 3306, Container: 3306}},
+				"db":        {{HostIP: "127.0.0.1", Host: 3306, Container: 3306}},
+			},
+			want: service.ValidationErrors{"Host port 127.0.0.1:3306 is published by both webserver and db"},
+		},
+		"random host ports": {
+			ports: map[string]dockercompose.Ports{
+				"webserver": {{Container: 80}},
+				"db":        {{Container: 80}},
+			},
+		},
+		"collision is reported once for each port": {
+			ports: map[string]dockercompose.Ports{
+				"webserver": {{Host: 80, Container: 80}},
+				"db":        {{Host: 80, Container: 3306}},
+				"redis":     {{Host: 80, Container: 6379}},
+			},
+			want: service.ValidationErrors{
+				"Host port 80 is published by both webserver and db",
+				"Host port 80 is published by both webserver and redis",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := assemble.CheckHostPorts(composeWithPorts(tc.ports))
+
+			if tc.want == nil {
+				if err != nil {
+					t.Fatalf("expected no errors, got: %v", err)
+				}
+
+				return
+			}
+
+			errs, ok := err.(*service.ValidationErrors)
+			if !ok {
+				t.Fatalf("expected validation errors, got: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.want, *errs); diff != "" {
+				t.Errorf("CheckHostPorts() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckLocalPorts(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %s", err)
+	}
+	defer listener.Close()
+
+	taken := listener.Addr().(*net.TCPAddr).Port
+
+	free, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %s", err)
+	}
+
+	freePort := free.Addr().(*net.TCPAddr).Port
+	_ = free.Close()
+
+	compose := composeWithPorts(map[string]dockercompose.Ports{
+		"webserver": {{HostIP: "127.0.0.1", Host: freePort, Container: 80}},
+		"db":        {{HostIP: "127.0.0.1", Host: taken, Container: 3306}},
+	})
+
+	err = assemble.CheckLocalPorts(compose)
+
+	errs, ok := err.(*service.ValidationErrors)
+	if !ok {
+		t.Fatalf("expected validation errors, got: %v", err)
+	}
+
+	want := service.ValidationErrors{fmt.Sprintf("Host port 127.0.0.1:%d of db is already in use", taken)}
+
+	if diff := cmp.Diff(want, *errs); diff != "" {
+		t.Errorf("CheckLocalPorts() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -21,6 +21,10 @@ func NewServiceAssembler(s service.SupportedService) ServiceAssembler {
 		return databaseAssembler()
 	case service.NodeJS:
 		return nodeJSAssembler()
+	case service.Redis:
+		return redisAssembler()
+	case service.MongoDB:
+		return mongoDBAssembler()
 	default:
 		return unknownAssembler()
 	}
@@ -40,8 +44,8 @@ func phpAssembler() ServiceAssembler {
 			o.apply(&options)
 		}
 
-		workDir := "/var/www"
-		appName := formatAppName(conf.AppName)
+		workDir := service.PHP.ProjectRootPath()
+		appName := conf.FormattedAppName()
 
 		s := dockercompose.Service{
 			Name:          "php-fpm",
@@ -49,7 +53,7 @@ func phpAssembler() ServiceAssembler {
 			Restart:       dockercompose.RestartPolicyUnlessStopped,
 			WorkingDir:    workDir,
 			Volumes: dockercompose.ServiceVolumes{
-				&dockercompose.ServiceVolume{Source: conf.ProjectRoot, Target: workDir},
+				projectRootVolume(conf, workDir),
 			},
 		}
 
@@ -60,6 +64,8 @@ func phpAssembler() ServiceAssembler {
 			}
 
 			s.Image = &dockercompose.Image{Name: appName}
+			// Only our Dockerfile enables php-fpm ping page and installs cgi-fcgi to query it
+			s.Healthcheck = phpFPMHealthcheck(conf)
 		} else {
 			s.Image = &dockercompose.Image{
 				Name: "php",
@@ -111,24 +117,24 @@ func nginxAssembler() ServiceAssembler {
 			},
 			ContainerName: "webserver",
 			Restart:       dockercompose.RestartPolicyUnlessStopped,
-			Ports: dockercompose.Ports{
-				&dockercompose.PortsMapping{Host: HTTPPort, Container: HTTPPort},
-				&dockercompose.PortsMapping{Host: HTTPSPort, Container: HTTPSPort},
-			},
 			Volumes: dockercompose.ServiceVolumes{
-				&dockercompose.ServiceVolume{Source: conf.ProjectRoot, Target: "/var/www"},
+				projectRootVolume(conf, service.Nginx.ProjectRootPath()),
 			},
 		}
 
+		publish(&s, conf.Services.Nginx.PortBinding, HTTPPort, HTTPPort)
+		publish(&s, conf.Services.Nginx.PortBinding, HTTPSPort, HTTPSPort)
+
 		applyMergeables(&options, &s)
 
 		return &s
 	}
 }
 
-func databaseAssembler() ServiceAssembler {
+// NewDatabaseAssembler creates ServiceAssembler for the specific database of the config
+func NewDatabaseAssembler(db *service.DatabaseConfig) ServiceAssembler {
 	return func(conf *service.FullConfig, opts ...Option) *dockercompose.Service {
-		if !conf.Services.IsPresent(service.Database) {
+		if db == nil {
 			return nil
 		}
 
@@ -140,25 +146,45 @@ func databaseAssembler() ServiceAssembler {
 			o.apply(&options)
 		}
 
+		name := db.ServiceName()
+
 		s := dockercompose.Service{
-			Name: "db",
+			Name: name,
 			Image: &dockercompose.Image{
-				Name: string(conf.Services.Database.System),
-				Tag:  conf.Services.Database.Version,
+				Name: db.System.Image(),
+				Tag:  db.Version,
 			},
-			ContainerName: "db",
+			ContainerName: name,
 			Restart:       dockercompose.RestartPolicyUnlessStopped,
-			Ports: dockercompose.Ports{
-				&dockercompose.PortsMapping{Host: conf.Services.Database.Port, Container: conf.Services.Database.Port},
-			},
 		}
 
+		publish(&s, db.PortBinding, db.Port, db.TargetPort())
+
+		if args := db.ServerArgs(); len(args) != 0 {
+			s.Command = append(dockercompose.Command{"postgres"}, args...)
+		}
+
+		s.Healthcheck = databaseHealthcheck(db)
+
 		applyMergeables(&options, &s)
 
 		return &s
 	}
 }
 
+// databaseAssembler assembles the first database of the config. Use NewDatabaseAssembler for the rest of them
+func databaseAssembler() ServiceAssembler {
+	return func(conf *service.FullConfig, opts ...Option) *dockercompose.Service {
+		databases := conf.Services.AllDatabases()
+
+		if len(databases) == 0 {
+			return nil
+		}
+
+		return NewDatabaseAssembler(databases[0])(conf, opts...)
+	}
+}
+
 func nodeJSAssembler() ServiceAssembler {
 	return func(conf *service.FullConfig, opts ...Option) *dockercompose.Service {
 		if !conf.Services.IsPresent(service.NodeJS) {
@@ -173,13 +199,13 @@ func nodeJSAssembler() ServiceAssembler {
 			o.apply(&options)
 		}
 
-		workDir := "/opt"
+		workDir := service.NodeJS.ProjectRootPath()
 
 		s := dockercompose.Service{
 			Name:          "nodejs",
 			ContainerName: "nodejs",
 			Volumes: dockercompose.ServiceVolumes{
-				&dockercompose.ServiceVolume{Source: conf.ProjectRoot, Target: workDir},
+				projectRootVolume(conf, workDir),
 			},
 			WorkingDir: workDir,
 		}
@@ -202,12 +228,95 @@ func nodeJSAssembler() ServiceAssembler {
 	}
 }
 
+func redisAssembler() ServiceAssembler {
+	return func(conf *service.FullConfig, opts ...Option) *dockercompose.Service {
+		if !conf.Services.IsPresent(service.Redis) {
+			return nil
+		}
+
+		options := options{
+			dockerfilePath: "",
+		}
+
+		for _, o := range opts {
+			o.apply(&options)
+		}
+
+		s := dockercompose.Service{
+			Name: "redis",
+			Image: &dockercompose.Image{
+				Name: "redis",
+				Tag:  conf.Services.Redis.Version,
+			},
+			ContainerName: "redis",
+			Restart:       dockercompose.RestartPolicyUnlessStopped,
+		}
+
+		publish(&s, conf.Services.Redis.PortBinding, conf.Services.Redis.Port, service.RedisPort)
+
+		if args := conf.Services.Redis.ServerArgs(); len(args) != 0 {
+			s.Command = append(dockercompose.Command{"redis-server"}, args...)
+		}
+
+		s.Healthcheck = redisHealthcheck(conf.Services.Redis)
+
+		applyMergeables(&options, &s)
+
+		return &s
+	}
+}
+
+func mongoDBAssembler() ServiceAssembler {
+	return func(conf *service.FullConfig, opts ...Option) *dockercompose.Service {
+		if !conf.Services.IsPresent(service.MongoDB) {
+			return nil
+		}
+
+		options := options{
+			dockerfilePath: "",
+		}
+
+		for _, o := range opts {
+			o.apply(&options)
+		}
+
+		s := dockercompose.Service{
+			Name: "mongodb",
+			Image: &dockercompose.Image{
+				Name: "mongo",
+				Tag:  conf.Services.MongoDB.Version,
+			},
+			ContainerName: "mongodb",
+			Restart:       dockercompose.RestartPolicyUnlessStopped,
+		}
+
+		publish(&s, conf.Services.MongoDB.PortBinding, conf.Services.MongoDB.Port, service.MongoDBPort)
+
+		s.Healthcheck = mongoDBHealthcheck()
+
+		applyMergeables(&options, &s)
+
+		return &s
+	}
+}
+
 func unknownAssembler() ServiceAssembler {
 	return func(conf *service.FullConfig, opts ...Option) *dockercompose.Service {
 		return nil
 	}
 }
 
+// publish maps host port to container port of the service according to the binding. Expose-only port is reachable
+// only by other services, so the host port is not used
+func publish(s *dockercompose.Service, binding service.PortBinding, host, container int) {
+	if binding.ExposeOnly {
+		s.Expose = append(s.Expose, &dockercompose.PortsMapping{Container: container})
+		return
+	}
+
+	s.Ports = append(s.Ports, &dockercompose.PortsMapping{HostIP: binding.HostIP, Host: host, Container: container})
+}
+
 func applyMergeables(opts *options, s *dockercompose.Service) {
 	if len(opts.volumes) != 0 {
 		s.Volumes = append(s.Volumes, opts.volumes...)
@@ -95,7 +95,7 @@ func TestPhpAssemble(t *testing.T) {
 			opts: []assemble.Option{
 				assemble.WithDockerfilePath("/home/test/app/.docker/php/Dockerfile"),
 				assemble.WithNetworks(dockercompose.ServiceNetworks{
-					&dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge},
+					{Network: &dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge}},
 				}),
 			},
 			want: &dockercompose.Service{
@@ -110,8 +110,17 @@ func TestPhpAssemble(t *testing.T) {
 				ContainerName: "test-app",
 				WorkingDir:    "/var/www",
 				Restart:       dockercompose.RestartPolicyUnlessStopped,
+				Healthcheck: &dockercompose.Healthcheck{
+					Test: []string{
+						"CMD-SHELL",
+						"SCRIPT_NAME=/ping SCRIPT_FILENAME=/ping REQUEST_METHOD=GET cgi-fcgi -bind -connect 127.0.0.1:9000 || exit 1",
+					},
+					Interval: "10s",
+					Timeout:  "5s",
+					Retries:  5,
+				},
 				Networks: dockercompose.ServiceNetworks{
-					&dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge},
+					{Network: &dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge}},
 				},
 				Volumes: dockercompose.ServiceVolumes{
 					&dockercompose.ServiceVolume{Source: "/home/test/app", Target: "/var/www"},
@@ -130,6 +139,25 @@ func TestPhpAssemble(t *testing.T) {
 	}
 }
 
+func TestPhpAssemble_HealthcheckUsesFastCGIPassPort(t *testing.T) {
+	conf := dummyConf()
+	conf.Services.Nginx.FastCGI.PassPort = 9001
+
+	got := assemble.NewServiceAssembler(service.PHP)(
+		conf,
+		assemble.WithDockerfilePath("/home/test/app/.docker/php/Dockerfile"),
+	)
+
+	want := []string{
+		"CMD-SHELL",
+		"SCRIPT_NAME=/ping SCRIPT_FILENAME=/ping REQUEST_METHOD=GET cgi-fcgi -bind -connect 127.0.0.1:9001 || exit 1",
+	}
+
+	if diff := cmp.Diff(want, got.Healthcheck.Test); diff != "" {
+		t.Errorf("PHP healthcheck mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestNginxAssemble(t *testing.T) {
 	assembler := assemble.NewServiceAssembler(service.Nginx)
 
@@ -169,7 +197,7 @@ func TestNginxAssemble(t *testing.T) {
 			conf: conf,
 			opts: []assemble.Option{
 				assemble.WithNetworks(dockercompose.ServiceNetworks{
-					&dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge},
+					{Network: &dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge}},
 				}),
 				assemble.WithVolumes(dockercompose.ServiceVolumes{
 					&dockercompose.ServiceVolume{Source: "./nginx/conf.d/", Target: "/etc/nginx/conf.d/"},
@@ -188,7 +216,7 @@ func TestNginxAssemble(t *testing.T) {
 					&dockercompose.PortsMapping{Host: 443, Container: 443},
 				},
 				Networks: dockercompose.ServiceNetworks{
-					&dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge},
+					{Network: &dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge}},
 				},
 				Volumes: dockercompose.ServiceVolumes{
 					&dockercompose.ServiceVolume{Source: "/home/test/app", Target: "/var/www"},
@@ -242,11 +270,17 @@ func TestDatabaseAssemble(t *testing.T) {
 			want: &dockercompose.Service{
 				Name: "db",
 				Image: &dockercompose.Image{
-					Name: string(service.MySQL),
+					Name: "mysql",
 					Tag:  "8.0",
 				},
 				ContainerName: "db",
 				Restart:       dockercompose.RestartPolicyUnlessStopped,
+				Healthcheck: &dockercompose.Healthcheck{
+					Test:     []string{"CMD", "mysqladmin", "ping", "-h", "127.0.0.1", "-P", "3306"},
+					Interval: "10s",
+					Timeout:  "5s",
+					Retries:  5,
+				},
 				Ports: dockercompose.Ports{
 					&dockercompose.PortsMapping{Host: 3306, Container: 3306},
 				},
@@ -255,7 +289,7 @@ func TestDatabaseAssemble(t *testing.T) {
 		"with options": {
 			opts: []assemble.Option{
 				assemble.WithNetworks(dockercompose.ServiceNetworks{
-					&dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge},
+					{Network: &dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge}},
 				}),
 				assemble.WithVolumes(dockercompose.ServiceVolumes{
 					&dockercompose.ServiceVolume{Source: "test-data", Target: "/var/lib/mysql"},
@@ -268,11 +302,17 @@ func TestDatabaseAssemble(t *testing.T) {
 			want: &dockercompose.Service{
 				Name: "db",
 				Image: &dockercompose.Image{
-					Name: string(service.MySQL),
+					Name: "mysql",
 					Tag:  "8.0",
 				},
 				ContainerName: "db",
 				Restart:       dockercompose.RestartPolicyUnlessStopped,
+				Healthcheck: &dockercompose.Healthcheck{
+					Test:     []string{"CMD", "mysqladmin", "ping", "-h", "127.0.0.1", "-P", "3306"},
+					Interval: "10s",
+					Timeout:  "5s",
+					Retries:  5,
+				},
 				Ports: dockercompose.Ports{
 					&dockercompose.PortsMapping{Host: 3306, Container: 3306},
 				},
@@ -281,7 +321,7 @@ func TestDatabaseAssemble(t *testing.T) {
 					"MYSQL_ROOT_PASSWORD": "secret-root",
 				},
 				Networks: dockercompose.ServiceNetworks{
-					&dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge},
+					{Network: &dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge}},
 				},
 				Volumes: dockercompose.ServiceVolumes{
 					&dockercompose.ServiceVolume{Source: "test-data", Target: "/var/lib/mysql"},
@@ -300,6 +340,156 @@ func TestDatabaseAssemble(t *testing.T) {
 	}
 }
 
+func TestDatabaseAssemble_OfficialImages(t *testing.T) {
+	assembler := assemble.NewServiceAssembler(service.Database)
+
+	tests := map[string]struct {
+		system service.SupportedSystem
+		want   *dockercompose.Image
+	}{
+		"mysql": {
+			system: service.MySQL,
+			want:   &dockercompose.Image{Name: "mysql", Tag: "8.0"},
+		},
+		"postgresql": {
+			system: service.PostgreSQL,
+			want:   &dockercompose.Image{Name: "postgres", Tag: "12.3"},
+		},
+		"mariadb": {
+			system: service.MariaDB,
+			want:   &dockercompose.Image{Name: "mariadb", Tag: "10.5"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			conf := dummyConf()
+			conf.Services.Database = &service.DatabaseConfig{System: tc.system}
+			conf.Services.Database.FillDefaultsIfNotSet()
+
+			got := assembler(conf)
+
+			if diff := cmp.Diff(tc.want, got.Image); diff != "" {
+				t.Fatalf("Database assembler image mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDatabaseAssemble_Ports(t *testing.T) {
+	assembler := assemble.NewServiceAssembler(service.Database)
+
+	tests := map[string]struct {
+		db         *service.DatabaseConfig
+		wantPorts  dockercompose.Ports
+		wantExpose dockercompose.Expose
+	}{
+		"host port differs from container port": {
+			db:        &service.DatabaseConfig{System: service.MySQL, Port: 3307},
+			wantPorts: dockercompose.Ports{{Host: 3307, Container: 3306}},
+		},
+		"container port": {
+			db: &service.DatabaseConfig{
+				System:        service.PostgreSQL,
+				Port:          5432,
+				ContainerPort: 6432,
+			},
+			wantPorts: dockercompose.Ports{{Host: 5432, Container: 6432}},
+		},
+		"host IP": {
+			db: &service.DatabaseConfig{
+				System:      service.MySQL,
+				Port:        3306,
+				PortBinding: service.PortBinding{HostIP: "127.0.0.1"},
+			},
+			wantPorts: dockercompose.Ports{{HostIP: "127.0.0.1", Host: 3306, Container: 3306}},
+		},
+		"expose-only": {
+			db: &service.DatabaseConfig{
+				System:      service.MySQL,
+				Port:        3307,
+				PortBinding: service.PortBinding{ExposeOnly: true},
+			},
+			wantExpose: dockercompose.Expose{{Container: 3306}},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			conf := dummyConf()
+			conf.Services.Database = tc.db
+
+			got := assembler(conf)
+
+			if diff := cmp.Diff(tc.wantPorts, got.Ports); diff != "" {
+				t.Errorf("Database assembler ports mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantExpose, got.Expose); diff != "" {
+				t.Errorf("Database assembler exposed ports mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDatabaseAssemble_Healthchecks(t *testing.T) {
+	assembler := assemble.NewServiceAssembler(service.Database)
+
+	tests := map[string]struct {
+		db   *service.DatabaseConfig
+		want []string
+	}{
+		"mysql": {
+			db:   &service.DatabaseConfig{System: service.MySQL},
+			want: []string{"CMD", "mysqladmin", "ping", "-h", "127.0.0.1", "-P", "3306"},
+		},
+		"mysql on another port": {
+			db:   &service.DatabaseConfig{System: service.MySQL, ContainerPort: 3307},
+			want: []string{"CMD", "mysqladmin", "ping", "-h", "127.0.0.1", "-P", "3307"},
+		},
+		"mariadb": {
+			db:   &service.DatabaseConfig{System: service.MariaDB},
+			want: []string{"CMD", "mariadb-admin", "ping", "-h", "127.0.0.1", "-P", "3306"},
+		},
+		"postgresql": {
+			db:   &service.DatabaseConfig{System: service.PostgreSQL},
+			want: []string{"CMD", "pg_isready", "-h", "127.0.0.1", "-p", "5432", "-U", "postgres"},
+		},
+		"postgresql with username": {
+			db: &service.DatabaseConfig{
+				System:      service.PostgreSQL,
+				Credentials: service.Credentials{Username: "app", Password: "secret"},
+			},
+			want: []string{"CMD", "pg_isready", "-h", "127.0.0.1", "-p", "5432", "-U", "app"},
+		},
+		"postgresql on another port": {
+			db: &service.DatabaseConfig{
+				System:        service.PostgreSQL,
+				ContainerPort: 6432,
+			},
+			want: []string{"CMD", "pg_isready", "-h", "127.0.0.1", "-p", "6432", "-U", "postgres"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			conf := dummyConf()
+			conf.Services.Database = tc.db
+			conf.Services.Database.FillDefaultsIfNotSet()
+
+			got := assembler(conf)
+
+			if got.Healthcheck == nil {
+				t.Fatalf("Database assembler did not add healthcheck")
+			}
+
+			if diff := cmp.Diff(tc.want, got.Healthcheck.Test); diff != "" {
+				t.Fatalf("Database assembler healthcheck mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestNodeJSAssemble(t *testing.T) {
 	assembler := assemble.NewServiceAssembler(service.NodeJS)
 
@@ -329,7 +519,7 @@ func TestNodeJSAssemble(t *testing.T) {
 			opts: []assemble.Option{
 				assemble.WithDockerfilePath("/home/test/app/.docker/node/Dockerfile"),
 				assemble.WithNetworks(dockercompose.ServiceNetworks{
-					&dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge},
+					{Network: &dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge}},
 				}),
 			},
 			want: &dockercompose.Service{
@@ -340,7 +530,7 @@ func TestNodeJSAssemble(t *testing.T) {
 				},
 				ContainerName: "nodejs",
 				Networks: dockercompose.ServiceNetworks{
-					&dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge},
+					{Network: &dockercompose.Network{Name: "test-app-network", Driver: dockercompose.NetworkDriverBridge}},
 				},
 				Volumes: dockercompose.ServiceVolumes{
 					&dockercompose.ServiceVolume{Source: conf.ProjectRoot, Target: "/opt"},
@@ -360,6 +550,166 @@ func TestNodeJSAssemble(t *testing.T) {
 	}
 }
 
+func TestRedisAssemble(t *testing.T) {
+	assembler := assemble.NewServiceAssembler(service.Redis)
+
+	assertAssemblerReturnsNilIfServiceIsNotPresent(t, assembler, service.Redis)
+
+	tests := map[string]struct {
+		redis *service.RedisConfig
+		opts  []assemble.Option
+		want  *dockercompose.Service
+	}{
+		"defaults": {
+			redis: &service.RedisConfig{Version: "6.0", Port: 6379},
+			want: &dockercompose.Service{
+				Name:          "redis",
+				Image:         &dockercompose.Image{Name: "redis", Tag: "6.0"},
+				ContainerName: "redis",
+				Restart:       dockercompose.RestartPolicyUnlessStopped,
+				Healthcheck: &dockercompose.Healthcheck{
+					Test:     []string{"CMD", "redis-cli", "ping"},
+					Interval: "10s",
+					Timeout:  "5s",
+					Retries:  5,
+				},
+				Ports: dockercompose.Ports{
+					&dockercompose.PortsMapping{Host: 6379, Container: 6379},
+				},
+			},
+		},
+		"with server args and options": {
+			redis: &service.RedisConfig{
+				Version:         "5",
+				Port:            6380,
+				Persistence:     true,
+				Password:        "secret",
+				MaxMemory:       "256mb",
+				MaxMemoryPolicy: "allkeys-lru",
+			},
+			opts: []assemble.Option{
+				assemble.WithVolumes(dockercompose.ServiceVolumes{
+					&dockercompose.ServiceVolume{Source: "test-app-redis-data", Target: "/data"},
+				}),
+			},
+			want: &dockercompose.Service{
+				Name:          "redis",
+				Image:         &dockercompose.Image{Name: "redis", Tag: "5"},
+				Command:       dockercompose.Command{"redis-server", "--appendonly", "yes", "--requirepass", "secret", "--maxmemory", "256mb", "--maxmemory-policy", "allkeys-lru"},
+				ContainerName: "redis",
+				Restart:       dockercompose.RestartPolicyUnlessStopped,
+				Healthcheck: &dockercompose.Healthcheck{
+					Test:     []string{"CMD", "redis-cli", "--no-auth-warning", "-a", "secret", "ping"},
+					Interval: "10s",
+					Timeout:  "5s",
+					Retries:  5,
+				},
+				Ports: dockercompose.Ports{
+					&dockercompose.PortsMapping{Host: 6380, Container: 6379},
+				},
+				Volumes: dockercompose.ServiceVolumes{
+					&dockercompose.ServiceVolume{Source: "test-app-redis-data", Target: "/data"},
+				},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			conf := dummyConf()
+			conf.Services.Redis = tc.redis
+
+			got := assembler(conf, tc.opts...)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("Redis assembler mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMongoDBAssemble(t *testing.T) {
+	assembler := assemble.NewServiceAssembler(service.MongoDB)
+
+	assertAssemblerReturnsNilIfServiceIsNotPresent(t, assembler, service.MongoDB)
+
+	tests := map[string]struct {
+		mongo *service.MongoDBConfig
+		opts  []assemble.Option
+		want  *dockercompose.Service
+	}{
+		"defaults": {
+			mongo: &service.MongoDBConfig{Version: "4.4", Port: 27017},
+			want: &dockercompose.Service{
+				Name:          "mongodb",
+				Image:         &dockercompose.Image{Name: "mongo", Tag: "4.4"},
+				ContainerName: "mongodb",
+				Restart:       dockercompose.RestartPolicyUnlessStopped,
+				Healthcheck: &dockercompose.Healthcheck{
+					Test: []string{
+						"CMD-SHELL",
+						"mongosh --quiet --eval 'db.runCommand({ping: 1})' || mongo --quiet --eval 'db.runCommand({ping: 1})'",
+					},
+					Interval: "10s",
+					Timeout:  "5s",
+					Retries:  5,
+				},
+				Ports: dockercompose.Ports{
+					&dockercompose.PortsMapping{Host: 27017, Container: 27017},
+				},
+			},
+		},
+		"with options": {
+			mongo: &service.MongoDBConfig{Version: "4.2", Port: 27018, Username: "root", Password: "secret"},
+			opts: []assemble.Option{
+				assemble.WithVolumes(dockercompose.ServiceVolumes{
+					&dockercompose.ServiceVolume{Source: "test-app-mongodb-data", Target: "/data/db"},
+				}),
+				assemble.WithEnvironment(dockercompose.Environment{
+					"MONGO_INITDB_ROOT_USERNAME": "root",
+					"MONGO_INITDB_ROOT_PASSWORD": "secret",
+				}),
+			},
+			want: &dockercompose.Service{
+				Name:          "mongodb",
+				Image:         &dockercompose.Image{Name: "mongo", Tag: "4.2"},
+				ContainerName: "mongodb",
+				Restart:       dockercompose.RestartPolicyUnlessStopped,
+				Healthcheck: &dockercompose.Healthcheck{
+					Test: []string{
+						"CMD-SHELL",
+						"mongosh --quiet --eval 'db.runCommand({ping: 1})' || mongo --quiet --eval 'db.runCommand({ping: 1})'",
+					},
+					Interval: "10s",
+					Timeout:  "5s",
+					Retries:  5,
+				},
+				Ports: dockercompose.Ports{
+					&dockercompose.PortsMapping{Host: 27018, Container: 27017},
+				},
+				Environment: dockercompose.Environment{
+					"MONGO_INITDB_ROOT_USERNAME": "root",
+					"MONGO_INITDB_ROOT_PASSWORD": "secret",
+				},
+				Volumes: dockercompose.ServiceVolumes{
+					&dockercompose.ServiceVolume{Source: "test-app-mongodb-data", Target: "/data/db"},
+				},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			conf := dummyConf()
+			conf.Services.MongoDB = tc.mongo
+
+			got := assembler(conf, tc.opts...)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("MongoDB assembler mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestUnknownAssemble(t *testing.T) {
 	assembler := assemble.NewServiceAssembler(service.SupportedService(100))
 
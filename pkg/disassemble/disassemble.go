@@ -0,0 +1,963 @@
+package disassemble
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+
+	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
+	"github.com/Bocmah/phpdocker-gen/pkg/assemble"
+	"github.com/Bocmah/phpdocker-gen/pkg/service"
+)
+
+// AppFs is the filesystem in use
+var AppFs = afero.NewOsFs()
+
+// dataPaths are paths inside containers of services where named volumes keeping data of the services are mounted
+var dataPaths = map[string]bool{
+	service.MySQL.DataPath():      true,
+	service.MariaDB.DataPath():    true,
+	service.PostgreSQL.DataPath(): true,
+	service.RedisDataPath:         true,
+	service.MongoDBDataPath:       true,
+}
+
+// Report is a collection of docker-compose setup parts which could not be mapped to service.FullConfig
+type Report []string
+
+func (r *Report) add(format string, args ...interface{}) {
+	*r = append(*r, fmt.Sprintf(format, args...))
+}
+
+// IsEmpty determines whether everything was mapped
+func (r Report) IsEmpty() bool {
+	return len(r) == 0
+}
+
+// DockerCompose reads docker-compose file at composePath together with Dockerfiles and configs of its services and
+// creates service.FullConfig which would reproduce them
+func DockerCompose(composePath string) (*service.FullConfig, Report, error) {
+	data, readErr := afero.ReadFile(AppFs, composePath)
+
+	if readErr != nil {
+		return nil, nil, fmt.Errorf("read compose file: %s", readErr)
+	}
+
+	compose, parseErr := dockercompose.Parse(data)
+
+	if parseErr != nil {
+		return nil, nil, parseErr
+	}
+
+	d := &disassembler{
+		composeDir: filepath.Dir(composePath),
+		conf:       &service.FullConfig{Services: &service.ServicesConfig{}},
+		mapped:     map[service.SupportedService]*dockercompose.Service{},
+		names:      map[string]string{},
+		argsRead:   map[string]bool{},
+	}
+
+	for _, s := range compose.Services {
+		d.disassembleService(s)
+	}
+
+	// Single database is kept under the database key, which is what most configs use
+	if dbs := d.conf.Services.Databases; len(dbs) == 1 {
+		d.conf.Services.Database = dbs[0]
+		d.conf.Services.Databases = nil
+	}
+
+	if d.conf.Services.PresentServicesCount() == 0 {
+		return nil, d.report, errors.New("compose file does not contain any supported services")
+	}
+
+	d.fillProject(compose)
+	d.readNetworks(compose)
+	d.readVolumes(compose)
+	d.removeAddedExtensions()
+	d.relativizeInitScripts()
+	d.reportIgnoredDirectives(compose)
+
+	return d.conf, d.report, nil
+}
+
+type disassembler struct {
+	composeDir string
+	conf       *service.FullConfig
+	mapped     map[service.SupportedService]*dockercompose.Service
+	// names are names of the generated docker-compose services keyed by names of the mapped services
+	names map[string]string
+	// argsRead are names of the mapped services whose command arguments are read one by one, so unsupported ones are
+	// already reported
+	argsRead map[string]bool
+	report   Report
+}
+
+func (d *disassembler) disassembleService(s *dockercompose.Service) {
+	df := d.readDockerfile(s)
+
+	image := imageOf(s, df)
+
+	serv, ok := recognize(image)
+
+	if !ok {
+		d.report.add("service %s: image %s is not supported", s.Name, image.Reference())
+		return
+	}
+
+	if mapped, ok := d.mapped[serv]; ok && serv != service.Database {
+		d.report.add("service %s: only one %s service is supported, %s is used", s.Name, serv, mapped.Name)
+		return
+	}
+
+	d.mapped[serv] = s
+	d.names[s.Name] = serv.ServiceName()
+
+	// Databases keep their names, see disassembleDatabase
+	if serv == service.Database {
+		d.names[s.Name] = s.Name
+	}
+
+	switch serv {
+	case service.PHP:
+		d.disassemblePHP(s, image, df)
+	case service.Nginx:
+		d.disassembleNginx(s)
+	case service.Database:
+		d.disassembleDatabase(s, image)
+	case service.NodeJS:
+		d.conf.Services.NodeJS = &service.NodeJSConfig{Version: image.Tag}
+
+		if image.Tag == "" {
+			d.conf.Services.NodeJS.Version = "latest"
+		}
+	case service.Redis:
+		d.disassembleRedis(s, image)
+	case service.MongoDB:
+		d.disassembleMongoDB(s, image)
+	}
+}
+
+func (d *disassembler) disassemblePHP(s *dockercompose.Service, image *dockercompose.Image, df *dockerfile) {
+	version := strings.SplitN(image.Tag, "-", 2)[0]
+
+	if !strings.Contains(image.Tag, "fpm") {
+		d.report.add("service %s: image %s is not php-fpm, php-fpm will be used instead", s.Name, image.Reference())
+	}
+
+	d.conf.Services.PHP = &service.PHPConfig{Version: version}
+
+	if df != nil {
+		d.conf.Services.PHP.Extensions = df.phpExtensions
+	}
+}
+
+func (d *disassembler) disassembleNginx(s *dockercompose.Service) {
+	nginx := &service.NginxConfig{}
+	ports := d.publishedPorts(s, &nginx.PortBinding)
+
+	for i, port := range ports {
+		host := port.Host
+
+		if host == 0 {
+			host = port.Container
+		}
+
+		if i == 0 {
+			nginx.HostIP = port.HostIP
+		}
+
+		switch {
+		case !isPlainPort(port) || port.HostIP != nginx.HostIP:
+			d.report.add("service %s: port %s is not supported", s.Name, port.Render())
+		case port.Container == 443 && nginx.HTTPSPort == 0:
+			nginx.HTTPSPort = host
+		case nginx.HTTPPort == 0:
+			nginx.HTTPPort = host
+		default:
+			d.report.add("service %s: port %s is not supported", s.Name, port.Render())
+		}
+	}
+
+	conf := d.readNginxConf(s)
+
+	if conf == nil {
+		d.report.add("service %s: nginx config was not found, serverName and fastCGI must be set manually", s.Name)
+	} else {
+		nginx.ServerName = conf.serverName
+
+		if conf.fastCGIPassPort != 0 || conf.fastCGIReadTimeout != 0 {
+			nginx.FastCGI = &service.FastCGI{
+				PassPort:           conf.fastCGIPassPort,
+				ReadTimeoutSeconds: conf.fastCGIReadTimeout,
+			}
+		}
+	}
+
+	d.conf.Services.Nginx = nginx
+}
+
+var environmentMapping = map[service.SupportedSystem]map[string]func(*service.DatabaseConfig, string){
+	service.MySQL: {
+		"MYSQL_ROOT_PASSWORD": func(c *service.DatabaseConfig, v string) { c.RootPassword = v },
+		"MYSQL_DATABASE":      func(c *service.DatabaseConfig, v string) { c.Name = v },
+		"MYSQL_USER":          func(c *service.DatabaseConfig, v string) { c.Username = v },
+		"MYSQL_PASSWORD":      func(c *service.DatabaseConfig, v string) { c.Password = v },
+	},
+	service.PostgreSQL: {
+		"POSTGRES_DB":       func(c *service.DatabaseConfig, v string) { c.Name = v },
+		"POSTGRES_USER":     func(c *service.DatabaseConfig, v string) { c.Username = v },
+		"POSTGRES_PASSWORD": func(c *service.DatabaseConfig, v string) { c.Password = v },
+	},
+	service.MariaDB: {
+		"MARIADB_ROOT_PASSWORD": func(c *service.DatabaseConfig, v string) { c.RootPassword = v },
+		"MARIADB_DATABASE":      func(c *service.DatabaseConfig, v string) { c.Name = v },
+		"MARIADB_USER":          func(c *service.DatabaseConfig, v string) { c.Username = v },
+		"MARIADB_PASSWORD":      func(c *service.DatabaseConfig, v string) { c.Password = v },
+	},
+}
+
+func (d *disassembler) disassembleDatabase(s *dockercompose.Service, image *dockercompose.Image) {
+	db := &service.DatabaseConfig{
+		System:  databaseImages[image.Name],
+		Version: image.Tag,
+	}
+
+	if s.Name != service.DefaultDatabaseService {
+		db.Service = s.Name
+	}
+
+	for _, vol := range s.Volumes {
+		if vol.Source == "" {
+			continue
+		}
+
+		if path.Dir(vol.Target) == service.InitScriptsPath {
+			db.InitScripts = append(db.InitScripts, d.resolve(vol.Source))
+		}
+
+		if configPath := db.System.ConfigPath(); configPath != "" && vol.Target == configPath {
+			d.readServerSettings(s, db, d.resolve(vol.Source))
+		}
+	}
+
+	if db.System == service.PostgreSQL {
+		d.readPostgreSQLArgs(s, db)
+	}
+
+	if port := d.readPort(s, &db.PortBinding, 0); port != nil {
+		db.Port = port.Host
+
+		if port.Host == 0 {
+			db.Port = port.Container
+		}
+
+		if port.Container != db.System.DefaultPort() {
+			db.ContainerPort = port.Container
+		}
+	}
+
+	d.readPortSetting(s, db)
+
+	mapping := environmentMapping[db.System]
+
+	for _, variable := range sortedVariables(s.Environment) {
+		if set, ok := mapping[variable]; ok {
+			set(db, s.Environment[variable])
+		} else {
+			d.report.add("service %s: environment variable %s is not supported", s.Name, variable)
+		}
+	}
+
+	d.conf.Services.Databases = append(d.conf.Services.Databases, db)
+}
+
+func (d *disassembler) disassembleRedis(s *dockercompose.Service, image *dockercompose.Image) {
+	redis := &service.RedisConfig{Version: image.Tag}
+
+	if image.Tag == "" {
+		redis.Version = "latest"
+	}
+
+	if port := d.readPort(s, &redis.PortBinding, service.RedisPort); port != nil {
+		redis.Port = port.Host
+
+		if port.Host == 0 {
+			redis.Port = port.Container
+		}
+	}
+
+	args := s.Command
+	d.argsRead[s.Name] = true
+
+	if len(args) != 0 && args[0] == "redis-server" {
+		args = args[1:]
+	}
+
+	for i := 0; i < len(args); i++ {
+		value := ""
+
+		if i+1 < len(args) {
+			value = args[i+1]
+		}
+
+		switch {
+		case args[i] == "--appendonly" && value == "yes":
+			redis.Persistence = true
+		case args[i] == "--requirepass" && value != "":
+			redis.Password = value
+		case args[i] == "--maxmemory" && value != "":
+			redis.MaxMemory = value
+		case args[i] == "--maxmemory-policy" && value != "":
+			redis.MaxMemoryPolicy = value
+		default:
+			d.report.add("service %s: command argument %s is not supported", s.Name, args[i])
+			continue
+		}
+
+		i++
+	}
+
+	d.conf.Services.Redis = redis
+}
+
+// publishedPorts returns ports of the service. Exposed ports are used if the service does not publish any, in which case
+// binding becomes expose-only
+func (d *disassembler) publishedPorts(s *dockercompose.Service, binding *service.PortBinding) dockercompose.Ports {
+	if len(s.Ports) == 0 {
+		binding.ExposeOnly = len(s.Expose) != 0
+
+		return dockercompose.Ports(s.Expose)
+	}
+
+	for _, port := range s.Expose {
+		d.report.add("service %s: exposed port %s is not supported", s.Name, port.Render())
+	}
+
+	return s.Ports
+}
+
+// readPort reads the only port of the service and fills the binding from it. Container port of the mapping must be
+// equal to containerPort unless it is zero. Other ports are reported
+func (d *disassembler) readPort(s *dockercompose.Service, binding *service.PortBinding, containerPort int) *dockercompose.PortsMapping {
+	var found *dockercompose.PortsMapping
+
+	for _, port := range d.publishedPorts(s, binding) {
+		if found != nil || !isPlainPort(port) || (containerPort != 0 && port.Container != containerPort) {
+			d.report.add("service %s: port %s is not supported", s.Name, port.Render())
+			continue
+		}
+
+		found = port
+	}
+
+	if found != nil {
+		binding.HostIP = found.HostIP
+	}
+
+	return found
+}
+
+// isPlainPort checks if the mapping is a single TCP port which can be expressed in the input file
+func isPlainPort(port *dockercompose.PortsMapping) bool {
+	return !port.IsRange() && (port.Protocol == "" || port.Protocol == dockercompose.PortProtocolTCP)
+}
+
+// readPortSetting removes port setting which is generated from containerPort. The server has to listen on the port
+// which is published, otherwise the setup can not be reproduced
+func (d *disassembler) readPortSetting(s *dockercompose.Service, db *service.DatabaseConfig) {
+	setting, ok := db.Settings["port"]
+	delete(db.Settings, "port")
+
+	if len(db.Settings) == 0 {
+		db.Settings = nil
+	}
+
+	if port := db.TargetPort(); port != db.System.DefaultPort() && setting != strconv.Itoa(port) {
+		d.report.add("service %s: server must be configured to listen on port %d with port setting", s.Name, port)
+	} else if ok && setting != strconv.Itoa(port) {
+		d.report.add("service %s: port setting %s does not match container port %d", s.Name, setting, port)
+	}
+}
+
+// readServerSettings fills database settings from the server configuration file
+func (d *disassembler) readServerSettings(s *dockercompose.Service, db *service.DatabaseConfig, confPath string) {
+	content, readErr := afero.ReadFile(AppFs, confPath)
+
+	if readErr != nil {
+		d.report.add("service %s: could not read server configuration: %s", s.Name, readErr)
+		return
+	}
+
+	if settings := parseServerSettings(string(content)); len(settings) != 0 {
+		db.Settings = settings
+	}
+}
+
+// readPostgreSQLArgs fills database settings from -c name=value arguments of postgres
+func (d *disassembler) readPostgreSQLArgs(s *dockercompose.Service, db *service.DatabaseConfig) {
+	args := s.Command
+
+	if len(args) == 0 || args[0] != "postgres" {
+		return
+	}
+
+	d.argsRead[s.Name] = true
+
+	for i := 1; i < len(args); i++ {
+		var parts []string
+
+		if args[i] == "-c" && i+1 < len(args) {
+			parts = strings.SplitN(args[i+1], "=", 2)
+		}
+
+		if len(parts) != 2 || parts[0] == "" {
+			d.report.add("service %s: command argument %s is not supported", s.Name, args[i])
+			continue
+		}
+
+		if db.Settings == nil {
+			db.Settings = map[string]string{}
+		}
+
+		db.Settings[parts[0]] = parts[1]
+		i++
+	}
+}
+
+var mongoDBEnvironmentMapping = map[string]func(*service.MongoDBConfig, string){
+	"MONGO_INITDB_ROOT_USERNAME": func(c *service.MongoDBConfig, v string) { c.Username = v },
+	"MONGO_INITDB_ROOT_PASSWORD": func(c *service.MongoDBConfig, v string) { c.Password = v },
+	"MONGO_INITDB_DATABASE":      func(c *service.MongoDBConfig, v string) { c.Name = v },
+}
+
+func (d *disassembler) disassembleMongoDB(s *dockercompose.Service, image *dockercompose.Image) {
+	mongo := &service.MongoDBConfig{Version: image.Tag}
+
+	if image.Tag == "" {
+		mongo.Version = "latest"
+	}
+
+	if port := d.readPort(s, &mongo.PortBinding, service.MongoDBPort); port != nil {
+		mongo.Port = port.Host
+
+		if port.Host == 0 {
+			mongo.Port = port.Container
+		}
+	}
+
+	for _, variable := range sortedVariables(s.Environment) {
+		if set, ok := mongoDBEnvironmentMapping[variable]; ok {
+			set(mongo, s.Environment[variable])
+		} else {
+			d.report.add("service %s: environment variable %s is not supported", s.Name, variable)
+		}
+	}
+
+	d.conf.Services.MongoDB = mongo
+}
+
+// fillProject fills project-level parameters based on the services which were mapped
+func (d *disassembler) fillProject(compose *dockercompose.Config) {
+	if php, ok := d.mapped[service.PHP]; ok {
+		d.conf.AppName = php.ContainerName
+
+		for _, vol := range php.Volumes {
+			if vol.Source != "" && vol.Target == php.WorkingDir {
+				d.conf.ProjectRoot = d.resolve(vol.Source)
+				d.conf.ProjectRootConsistency = string(vol.Consistency)
+			}
+		}
+
+		if d.conf.ProjectRoot == "" && php.Build != nil {
+			d.conf.ProjectRoot = d.resolve(php.Build.Context)
+		}
+	}
+
+	if d.conf.AppName == "" && len(compose.Networks) != 0 {
+		d.conf.AppName = strings.TrimSuffix(compose.Networks[0].Name, "-network")
+	}
+
+	if d.conf.ProjectRoot == "" {
+		d.report.add("project root could not be determined, directory of the compose file is used")
+		d.conf.ProjectRoot = d.composeDir
+	}
+
+	if d.conf.AppName == "" {
+		d.conf.AppName = filepath.Base(d.conf.ProjectRoot)
+	}
+
+	if d.conf.GetOutputPath() != d.composeDir {
+		d.conf.OutputPath = d.composeDir
+	}
+}
+
+// readNetworks reads top-level networks together with services attached to them. Nothing is read when compose has
+// only the default network of the app, since it is created anyway
+func (d *disassembler) readNetworks(compose *dockercompose.Config) {
+	members := map[string]map[string]*dockercompose.ServiceNetwork{}
+
+	for _, s := range compose.Services {
+		name, ok := d.names[s.Name]
+
+		if !ok {
+			continue
+		}
+
+		for _, network := range s.Networks {
+			if members[network.Name] == nil {
+				members[network.Name] = map[string]*dockercompose.ServiceNetwork{}
+			}
+
+			members[network.Name][name] = network
+		}
+	}
+
+	if d.isDefaultNetwork(compose.Networks, members) {
+		return
+	}
+
+	for _, network := range compose.Networks {
+		if network.Driver != "" && !service.IsSupportedNetworkDriver(string(network.Driver)) {
+			d.report.add("network %s: driver %s is not supported", network.Name, network.Driver)
+			continue
+		}
+
+		if len(members[network.Name]) == 0 {
+			d.report.add("network %s: no supported service is attached to it", network.Name)
+			continue
+		}
+
+		conf := &service.NetworkConfig{
+			Name:     network.Name,
+			Driver:   string(network.Driver),
+			External: network.External,
+			Services: d.networkServices(members[network.Name]),
+		}
+
+		d.readIPAM(network, conf)
+
+		d.conf.Networks = append(d.conf.Networks, conf)
+	}
+}
+
+// isDefaultNetwork checks if networks consist of the default network of the app which all services are attached to.
+// Compose without networks is treated the same way
+func (d *disassembler) isDefaultNetwork(networks dockercompose.Networks, members map[string]map[string]*dockercompose.ServiceNetwork) bool {
+	if len(networks) != 1 {
+		return len(networks) == 0
+	}
+
+	network := networks[0]
+	appName := strings.ReplaceAll(strings.ToLower(d.conf.AppName), " ", "-")
+
+	if network.Name != appName+"-network" || network.Driver != dockercompose.NetworkDriverBridge {
+		return false
+	}
+
+	if network.External || network.IPAM != nil {
+		return false
+	}
+
+	return d.networkServices(members[network.Name]) == nil
+}
+
+// networkServices returns services of the network. Nil is returned when all services are attached to the network
+// without aliases and addresses
+func (d *disassembler) networkServices(members map[string]*dockercompose.ServiceNetwork) map[string]*service.NetworkMemberConfig {
+	services := map[string]*service.NetworkMemberConfig{}
+	withOptions := false
+
+	for name, member := range members {
+		services[name] = nil
+
+		if len(member.Aliases) != 0 || member.IPv4Address != "" {
+			services[name] = &service.NetworkMemberConfig{Aliases: member.Aliases, IPv4Address: member.IPv4Address}
+			withOptions = true
+		}
+	}
+
+	if !withOptions && len(services) == len(d.names) {
+		return nil
+	}
+
+	return services
+}
+
+func (d *disassembler) readIPAM(network *dockercompose.Network, conf *service.NetworkConfig) {
+	if network.IPAM == nil {
+		return
+	}
+
+	if driver := network.IPAM.Driver; driver != "" && driver != "default" {
+		d.report.add("network %s: IPAM driver %s is not supported", network.Name, driver)
+	}
+
+	if len(network.IPAM.Config) == 0 {
+		return
+	}
+
+	if len(network.IPAM.Config) > 1 {
+		d.report.add("network %s: only the first IPAM pool is used", network.Name)
+	}
+
+	pool := network.IPAM.Config[0]
+	conf.Subnet = pool.Subnet
+	conf.Gateway = pool.Gateway
+	conf.IPRange = pool.IPRange
+}
+
+// readVolumes reads named volumes which are mounted to the services in addition to the volumes keeping their data
+func (d *disassembler) readVolumes(compose *dockercompose.Config) {
+	mounts := map[string]map[string]*service.VolumeMountConfig{}
+	data := map[string]bool{}
+
+	for _, s := range compose.Services {
+		name, ok := d.names[s.Name]
+
+		if !ok {
+			continue
+		}
+
+		for _, vol := range s.Volumes {
+			if vol.EffectiveType() != dockercompose.VolumeTypeVolume || vol.Source == "" {
+				continue
+			}
+
+			if dataPaths[vol.Target] {
+				data[vol.Source] = true
+				continue
+			}
+
+			if mounts[vol.Source] == nil {
+				mounts[vol.Source] = map[string]*service.VolumeMountConfig{}
+			}
+
+			mounts[vol.Source][name] = &service.VolumeMountConfig{Target: vol.Target, ReadOnly: vol.ReadOnly}
+		}
+	}
+
+	for _, volume := range compose.Volumes {
+		if len(mounts[volume.Name]) == 0 {
+			if !data[volume.Name] {
+				d.report.add("volume %s: it is not mounted to any supported service", volume.Name)
+			}
+
+			continue
+		}
+
+		d.conf.Volumes = append(d.conf.Volumes, &service.VolumeConfig{
+			Name:       volume.Name,
+			Driver:     string(volume.Driver),
+			DriverOpts: volume.DriverOpts,
+			Labels:     volume.Labels,
+			External:   volume.External,
+			Services:   mounts[volume.Name],
+		})
+	}
+}
+
+// environmentIgnorers are services whose environment variables are not mapped to the config
+var environmentIgnorers = []service.SupportedService{service.PHP, service.Nginx, service.NodeJS, service.Redis}
+
+// directiveValues are values of the directive in the imported and in the generated service
+type directiveValues struct {
+	name      string
+	original  interface{}
+	generated interface{}
+}
+
+// reportIgnoredDirectives reports directives of the mapped services which the setup generated from the config would not
+// reproduce
+func (d *disassembler) reportIgnoredDirectives(compose *dockercompose.Config) {
+	generated := map[string]*dockercompose.Service{}
+
+	if conf, err := d.filledConfig(); err == nil {
+		for _, s := range assemble.DockerCompose(conf).Services {
+			generated[s.Name] = s
+		}
+	}
+
+	for _, s := range compose.Services {
+		name, ok := d.names[s.Name]
+
+		if !ok {
+			continue
+		}
+
+		g := generated[name]
+
+		if g == nil {
+			g = &dockercompose.Service{}
+		}
+
+		directives := []directiveValues{
+			{"entrypoint", s.Entrypoint, g.Entrypoint},
+			{"user", s.User, g.User},
+			{"restart", s.Restart, g.Restart},
+			{"depends_on", d.dependencies(s.DependsOn), g.DependsOn},
+			{"healthcheck", s.Healthcheck, g.Healthcheck},
+			{"env_file", s.EnvFile, g.EnvFile},
+			{"tmpfs", s.Tmpfs, g.Tmpfs},
+			{"extra_hosts", s.ExtraHosts, g.ExtraHosts},
+			{"labels", s.Labels, g.Labels},
+			{"ulimits", s.Ulimits, g.Ulimits},
+			{"cap_add", s.CapAdd, g.CapAdd},
+			{"logging", s.Logging, g.Logging},
+			{"stop_grace_period", s.StopGracePeriod, g.StopGracePeriod},
+			{"profiles", s.Profiles, g.Profiles},
+		}
+
+		if !d.argsRead[s.Name] {
+			directives = append(directives, directiveValues{"command", s.Command, g.Command})
+		}
+
+		for _, directive := range directives {
+			if !isEmpty(directive.original) && !reflect.DeepEqual(directive.original, directive.generated) {
+				d.report.add("service %s: %s is not supported", s.Name, directive.name)
+			}
+		}
+
+		for _, serv := range environmentIgnorers {
+			if name != serv.ServiceName() {
+				continue
+			}
+
+			for _, variable := range sortedVariables(s.Environment) {
+				d.report.add("service %s: environment variable %s is not supported", s.Name, variable)
+			}
+		}
+	}
+}
+
+// filledConfig returns a copy of the config with default parameters filled, so it can be assembled without changing
+// the config itself
+func (d *disassembler) filledConfig() (*service.FullConfig, error) {
+	data, marshalErr := yaml.Marshal(d.conf)
+
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+
+	conf := &service.FullConfig{}
+
+	if unmarshalErr := yaml.Unmarshal(data, conf); unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	conf.FillDefaultsIfNotSet()
+
+	return conf, nil
+}
+
+// dependencies translates dependencies to the names of the generated services. Dependencies on the services which were
+// not mapped keep their names
+func (d *disassembler) dependencies(deps dockercompose.Dependencies) dockercompose.Dependencies {
+	var translated dockercompose.Dependencies
+
+	for _, dep := range deps {
+		name, ok := d.names[dep.Service]
+
+		if !ok {
+			name = dep.Service
+		}
+
+		condition := dep.Condition
+
+		if condition == "" {
+			condition = dockercompose.ConditionServiceStarted
+		}
+
+		translated = append(translated, &dockercompose.Dependency{Service: name, Condition: condition})
+	}
+
+	return translated
+}
+
+// relativizeInitScripts makes paths to database init scripts inside the project root relative to it
+func (d *disassembler) relativizeInitScripts() {
+	for _, db := range d.conf.Services.AllDatabases() {
+		for i, script := range db.InitScripts {
+			rel, relErr := filepath.Rel(d.conf.ProjectRoot, script)
+
+			if relErr == nil && !strings.HasPrefix(rel, "..") {
+				db.InitScripts[i] = rel
+			}
+		}
+	}
+}
+
+// removeAddedExtensions removes extensions which are added automatically for the database, Redis and MongoDB in use
+func (d *disassembler) removeAddedExtensions() {
+	if !d.conf.Services.IsPresent(service.PHP) {
+		return
+	}
+
+	added := &service.PHPConfig{}
+
+	for _, db := range d.conf.Services.AllDatabases() {
+		added.AddDatabaseExtension(db.System)
+	}
+
+	if d.conf.Services.IsPresent(service.Redis) {
+		added.AddExtension("redis")
+	}
+
+	if d.conf.Services.IsPresent(service.MongoDB) {
+		added.AddExtension("mongodb")
+	}
+
+	var extensions []string
+
+	for _, ext := range d.conf.Services.PHP.Extensions {
+		if !added.HasExtension(ext) {
+			extensions = append(extensions, ext)
+		}
+	}
+
+	d.conf.Services.PHP.Extensions = extensions
+}
+
+func (d *disassembler) readDockerfile(s *dockercompose.Service) *dockerfile {
+	if s.Build == nil {
+		return nil
+	}
+
+	context := d.resolve(s.Build.Context)
+	dockerfilePath := s.Build.Dockerfile
+
+	if dockerfilePath == "" {
+		dockerfilePath = "Dockerfile"
+	}
+
+	if !filepath.IsAbs(dockerfilePath) {
+		dockerfilePath = filepath.Join(context, dockerfilePath)
+	}
+
+	content, readErr := afero.ReadFile(AppFs, dockerfilePath)
+
+	if readErr != nil {
+		d.report.add("service %s: could not read Dockerfile: %s", s.Name, readErr)
+		return nil
+	}
+
+	return parseDockerfile(string(content))
+}
+
+func (d *disassembler) readNginxConf(s *dockercompose.Service) *nginxConf {
+	for _, vol := range s.Volumes {
+		if vol.Source == "" || !strings.HasPrefix(vol.Target, "/etc/nginx/conf.d") {
+			continue
+		}
+
+		confPath := d.resolve(vol.Source)
+
+		if !strings.HasSuffix(vol.Target, ".conf") {
+			confPath = filepath.Join(confPath, "app.conf")
+		}
+
+		if content, readErr := afero.ReadFile(AppFs, confPath); readErr == nil {
+			return parseNginxConf(string(content))
+		}
+	}
+
+	return nil
+}
+
+func (d *disassembler) resolve(p string) string {
+	if filepath.IsAbs(p) {
+		return filepath.Clean(p)
+	}
+
+	return filepath.Join(d.composeDir, p)
+}
+
+// imageOf returns base image of the service. Base image from the Dockerfile takes precedence over image directive,
+// because image directive of the service with build only names the resulting image
+func imageOf(s *dockercompose.Service, df *dockerfile) *dockercompose.Image {
+	image := &dockercompose.Image{}
+
+	if df != nil {
+		image = dockercompose.ParseImage(df.baseImage)
+	} else if s.Image != nil {
+		*image = *s.Image
+	}
+
+	image.Name = officialImageName(image.Name)
+
+	return image
+}
+
+// officialImagePrefixes are prefixes under which official images may be referenced (e.g. docker.io/library/nginx)
+var officialImagePrefixes = []string{"index.docker.io/library/", "docker.io/library/", "library/"}
+
+// officialImageName strips the registry and library prefix from the name of the official image. Names of other images
+// are kept as is, so they are not mistaken for official ones (e.g. mycorp/php)
+func officialImageName(name string) string {
+	for _, prefix := range officialImagePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return strings.TrimPrefix(name, prefix)
+		}
+	}
+
+	return name
+}
+
+var databaseImages = map[string]service.SupportedSystem{
+	"mysql":    service.MySQL,
+	"postgres": service.PostgreSQL,
+	"mariadb":  service.MariaDB,
+}
+
+func recognize(image *dockercompose.Image) (service.SupportedService, bool) {
+	if _, ok := databaseImages[image.Name]; ok {
+		return service.Database, true
+	}
+
+	switch image.Name {
+	case "php":
+		return service.PHP, true
+	case "nginx":
+		return service.Nginx, true
+	case "node":
+		return service.NodeJS, true
+	case "redis":
+		return service.Redis, true
+	case "mongo":
+		return service.MongoDB, true
+	default:
+		return 0, false
+	}
+}
+
+// isEmpty determines whether directive is not set
+func isEmpty(directive interface{}) bool {
+	v := reflect.ValueOf(directive)
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+func sortedVariables(env dockercompose.Environment) []string {
+	variables := make([]string, 0, len(env))
+
+	for variable := range env {
+		variables = append(variables, variable)
+	}
+
+	sort.Strings(variables)
+
+	return variables
+}
@@ -0,0 +1,686 @@
+package disassemble_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+
+	"github.com/Bocmah/phpdocker-gen/pkg/assemble"
+	"github.com/Bocmah/phpdocker-gen/pkg/disassemble"
+	"github.com/Bocmah/phpdocker-gen/pkg/render"
+	"github.com/Bocmah/phpdocker-gen/pkg/service"
+)
+
+func writeFile(t *testing.T, fs afero.Fs, path, content string) {
+	t.Helper()
+
+	if err := afero.WriteFile(fs, path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write file %s: %s", path, err)
+	}
+}
+
+func TestDockerCompose_ReproducesGeneratedSetup(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	render.AppFs = fs
+	disassemble.AppFs = fs
+
+	want := &service.FullConfig{
+		AppName:                "awesome-app",
+		ProjectRoot:            "/home/test/app",
+		OutputPath:             "/home/test/docker",
+		ProjectRootConsistency: "cached",
+		Services: &service.ServicesConfig{
+			PHP: &service.PHPConfig{
+				Version:    "7.3",
+				Extensions: []string{"mbstring", "exif", "zip"},
+			},
+			Nginx: &service.NginxConfig{
+				HTTPPort:   8080,
+				ServerName: "awesome",
+				FastCGI: &service.FastCGI{
+					PassPort:           9001,
+					ReadTimeoutSeconds: 30,
+				},
+			},
+			Database: &service.DatabaseConfig{
+				System:  service.MySQL,
+				Version: "5.7",
+				Name:    "awesome-db",
+				Port:    3307,
+				Credentials: service.Credentials{
+					Username:     "awesome",
+					Password:     "secret: #1",
+					RootPassword: "root",
+				},
+			},
+			NodeJS: &service.NodeJSConfig{
+				Version: "14",
+			},
+			Redis: &service.RedisConfig{
+				Version:         "5",
+				Port:            6380,
+				Persistence:     true,
+				Password:        "secret",
+				MaxMemory:       "256mb",
+				MaxMemoryPolicy: "allkeys-lru",
+			},
+			MongoDB: &service.MongoDBConfig{
+				Version:  "4.2",
+				Port:     27018,
+				Name:     "awesome",
+				Username: "root",
+				Password: "secret",
+			},
+		},
+	}
+
+	want.FillDefaultsIfNotSet()
+
+	if err := render.Generate(want, assemble.DockerCompose(want)); err != nil {
+		t.Fatalf("failed to generate setup: %s", err)
+	}
+
+	got, report, err := disassemble.DockerCompose(want.GetDockerComposePath())
+
+	if err != nil {
+		t.Fatalf("encountered error when disassembling generated setup: %s", err)
+	}
+
+	if !report.IsEmpty() {
+		t.Errorf("generated setup was not fully imported: %v", report)
+	}
+
+	got.FillDefaultsIfNotSet()
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DockerCompose() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDockerCompose_ReproducesMultipleDatabases(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	render.AppFs = fs
+	disassemble.AppFs = fs
+
+	want := &service.FullConfig{
+		AppName:     "awesome-app",
+		ProjectRoot: "/home/test/app",
+		OutputPath:  "/home/test/docker",
+		Services: &service.ServicesConfig{
+			PHP: &service.PHPConfig{
+				Version:    "7.4",
+				Extensions: []string{"mbstring"},
+			},
+			Nginx: &service.NginxConfig{
+				ServerName: "awesome",
+			},
+			Databases: []*service.DatabaseConfig{
+				{
+					System:      service.MySQL,
+					Credentials: service.Credentials{RootPassword: "root"},
+					Settings:    map[string]string{"sql_mode": "STRICT_TRANS_TABLES,NO_ZERO_DATE"},
+				},
+				{
+					Service:       "analytics",
+					System:        service.PostgreSQL,
+					Name:          "analytics",
+					ContainerPort: 6432,
+					Credentials:   service.Credentials{Password: "secret"},
+					InitScripts:   []string{"database/analytics.sql", "/opt/dumps/events.sql.gz"},
+					Settings:      map[string]string{"max_connections": "200", "work_mem": "4MB"},
+				},
+			},
+		},
+	}
+
+	want.FillDefaultsIfNotSet()
+
+	if err := render.Generate(want, assemble.DockerCompose(want)); err != nil {
+		t.Fatalf("failed to generate setup: %s", err)
+	}
+
+	got, report, err := disassemble.DockerCompose(want.GetDockerComposePath())
+
+	if err != nil {
+		t.Fatalf("encountered error when disassembling generated setup: %s", err)
+	}
+
+	if !report.IsEmpty() {
+		t.Errorf("generated setup was not fully imported: %v", report)
+	}
+
+	got.FillDefaultsIfNotSet()
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DockerCompose() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDockerCompose_ReportsUnmappedParts(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	disassemble.AppFs = fs
+
+	writeFile(t, fs, "/home/test/app/docker-compose.yml", `version: "3.8"
+services:
+  app:
+    build: .
+    container_name: legacy
+    working_dir: /var/www
+    volumes:
+      - ./:/var/www
+  cache:
+    image: memcached:1.6
+  db:
+    image: mysql:5.7
+    ports:
+      - "3306:3306"
+      - "33060:33060"
+    environment:
+      MYSQL_ROOT_PASSWORD: root
+      MYSQL_ALLOW_EMPTY_PASSWORD: "yes"
+  db-replica:
+    image: mysql:5.7
+  web:
+    image: nginx
+    ports:
+      - "80:80"
+`)
+	writeFile(t, fs, "/home/test/app/Dockerfile", `FROM php:7.2-fpm
+RUN docker-php-ext-install pdo_mysql bcmath
+`)
+
+	got, report, err := disassemble.DockerCompose("/home/test/app/docker-compose.yml")
+
+	if err != nil {
+		t.Fatalf("encountered error when disassembling correct setup: %s", err)
+	}
+
+	want := &service.FullConfig{
+		AppName:     "legacy",
+		ProjectRoot: "/home/test/app",
+		OutputPath:  "/home/test/app",
+		Services: &service.ServicesConfig{
+			PHP: &service.PHPConfig{
+				Version:    "7.2",
+				Extensions: []string{"bcmath"},
+			},
+			Nginx: &service.NginxConfig{
+				HTTPPort: 80,
+			},
+			Databases: []*service.DatabaseConfig{
+				{
+					System:      service.MySQL,
+					Version:     "5.7",
+					Port:        3306,
+					Credentials: service.Credentials{RootPassword: "root"},
+				},
+				{
+					Service: "db-replica",
+					System:  service.MySQL,
+					Version: "5.7",
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DockerCompose() mismatch (-want +got):\n%s", diff)
+	}
+
+	wantReport := disassemble.Report{
+		"service cache: image memcached:1.6 is not supported",
+		`service db: port "33060:33060" is not supported`,
+		"service db: environment variable MYSQL_ALLOW_EMPTY_PASSWORD is not supported",
+		"service web: nginx config was not found, serverName and fastCGI must be set manually",
+	}
+
+	if diff := cmp.Diff(wantReport, report); diff != "" {
+		t.Errorf("Report mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDockerCompose_ReadsPortBindings(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	disassemble.AppFs = fs
+
+	writeFile(t, fs, "/home/test/app/docker-compose.yml", `version: "3.8"
+services:
+  db:
+    image: mysql:8.0
+    ports:
+      - "127.0.0.1:3307:3306"
+    environment:
+      MYSQL_ROOT_PASSWORD: root
+  analytics:
+    image: postgres:12.3
+    expose:
+      - "6432"
+    environment:
+      POSTGRES_PASSWORD: secret
+  redis:
+    image: redis:6.0
+    ports:
+      - "127.0.0.1:6379:6379"
+      - "6379:6379/udp"
+  web:
+    image: nginx:alpine
+    ports:
+      - "127.0.0.1:8080:8080"
+      - "8443:443"
+      - "9000-9010:9000-9010"
+`)
+
+	got, report, err := disassemble.DockerCompose("/home/test/app/docker-compose.yml")
+
+	if err != nil {
+		t.Fatalf("encountered error when disassembling correct setup: %s", err)
+	}
+
+	want := &service.ServicesConfig{
+		Nginx: &service.NginxConfig{
+			HTTPPort:    8080,
+			PortBinding: service.PortBinding{HostIP: "127.0.0.1"},
+		},
+		Databases: []*service.DatabaseConfig{
+			{
+				System:      service.MySQL,
+				Version:     "8.0",
+				Port:        3307,
+				PortBinding: service.PortBinding{HostIP: "127.0.0.1"},
+				Credentials: service.Credentials{RootPassword: "root"},
+			},
+			{
+				Service:       "analytics",
+				System:        service.PostgreSQL,
+				Version:       "12.3",
+				Port:          6432,
+				ContainerPort: 6432,
+				PortBinding:   service.PortBinding{ExposeOnly: true},
+				Credentials:   service.Credentials{Password: "secret"},
+			},
+		},
+		Redis: &service.RedisConfig{
+			Version:     "6.0",
+			Port:        6379,
+			PortBinding: service.PortBinding{HostIP: "127.0.0.1"},
+		},
+	}
+
+	if diff := cmp.Diff(want, got.Services); diff != "" {
+		t.Errorf("DockerCompose() services mismatch (-want +got):\n%s", diff)
+	}
+
+	wantReport := disassemble.Report{
+		"service analytics: server must be configured to listen on port 6432 with port setting",
+		`service redis: port "6379:6379/udp" is not supported`,
+		`service web: port "8443:443" is not supported`,
+		`service web: port "9000-9010:9000-9010" is not supported`,
+		"service web: nginx config was not found, serverName and fastCGI must be set manually",
+		"project root could not be determined, directory of the compose file is used",
+	}
+
+	if diff := cmp.Diff(wantReport, report); diff != "" {
+		t.Errorf("Report mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDockerCompose_ReadsNetworks(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	disassemble.AppFs = fs
+
+	writeFile(t, fs, "/home/test/app/docker-compose.yml", `version: "3.8"
+services:
+  db:
+    image: mysql:8.0
+    environment:
+      MYSQL_ROOT_PASSWORD: root
+    networks:
+      backend:
+        ipv4_address: 172.28.5.10
+  web:
+    image: nginx:alpine
+    networks:
+      backend:
+      proxy:
+        aliases:
+          - app.test
+  cache:
+    image: memcached
+    networks:
+      - cache
+networks:
+  backend:
+    driver: bridge
+    ipam:
+      config:
+        - subnet: 172.28.0.0/16
+          gateway: 172.28.0.1
+        - subnet: 172.29.0.0/16
+  proxy:
+    external: true
+  cache:
+    driver: bridge
+  swarm:
+    driver: host
+`)
+
+	got, report, err := disassemble.DockerCompose("/home/test/app/docker-compose.yml")
+
+	if err != nil {
+		t.Fatalf("encountered error when disassembling correct setup: %s", err)
+	}
+
+	want := []*service.NetworkConfig{
+		{
+			Name:    "backend",
+			Driver:  "bridge",
+			Subnet:  "172.28.0.0/16",
+			Gateway: "172.28.0.1",
+			Services: map[string]*service.NetworkMemberConfig{
+				"db":        {IPv4Address: "172.28.5.10"},
+				"webserver": nil,
+			},
+		},
+		{
+			Name:     "proxy",
+			External: true,
+			Services: map[string]*service.NetworkMemberConfig{
+				"webserver": {Aliases: []string{"app.test"}},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, got.Networks); diff != "" {
+		t.Errorf("DockerCompose() networks mismatch (-want +got):\n%s", diff)
+	}
+
+	wantReport := disassemble.Report{
+		"service web: nginx config was not found, serverName and fastCGI must be set manually",
+		"service cache: image memcached is not supported",
+		"project root could not be determined, directory of the compose file is used",
+		"network backend: only the first IPAM pool is used",
+		"network cache: no supported service is attached to it",
+		"network swarm: driver host is not supported",
+	}
+
+	if diff := cmp.Diff(wantReport, report); diff != "" {
+		t.Errorf("DockerCompose() report mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDockerCompose_ReadsVolumes(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	disassemble.AppFs = fs
+
+	writeFile(t, fs, "/home/test/app/docker-compose.yml", `version: "3.8"
+services:
+  db:
+    image: mysql:8.0
+    environment:
+      MYSQL_ROOT_PASSWORD: root
+    volumes:
+      - app-data:/var/lib/mysql
+      - shared-dumps:/dumps:ro
+  nodejs:
+    image: node:14
+    volumes:
+      - /home/test/app:/var/www
+      - node-modules:/var/www/node_modules
+      - shared-dumps:/var/www/dumps
+volumes:
+  app-data:
+  node-modules:
+    driver_opts:
+      type: tmpfs
+      device: tmpfs
+    labels:
+      com.example.app: app
+  shared-dumps:
+    external: true
+  unused:
+`)
+
+	got, report, err := disassemble.DockerCompose("/home/test/app/docker-compose.yml")
+
+	if err != nil {
+		t.Fatalf("encountered error when disassembling correct setup: %s", err)
+	}
+
+	want := []*service.VolumeConfig{
+		{
+			Name:       "node-modules",
+			Driver:     "local",
+			DriverOpts: map[string]string{"type": "tmpfs", "device": "tmpfs"},
+			Labels:     map[string]string{"com.example.app": "app"},
+			Services: map[string]*service.VolumeMountConfig{
+				"nodejs": {Target: "/var/www/node_modules"},
+			},
+		},
+		{
+			Name:     "shared-dumps",
+			External: true,
+			Services: map[string]*service.VolumeMountConfig{
+				"db":     {Target: "/dumps", ReadOnly: true},
+				"nodejs": {Target: "/var/www/dumps"},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, got.Volumes); diff != "" {
+		t.Errorf("DockerCompose() volumes mismatch (-want +got):\n%s", diff)
+	}
+
+	wantReport := disassemble.Report{
+		"project root could not be determined, directory of the compose file is used",
+		"volume unused: it is not mounted to any supported service",
+	}
+
+	if diff := cmp.Diff(wantReport, report); diff != "" {
+		t.Errorf("DockerCompose() report mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDockerCompose_ReportsIgnoredDirectives(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	disassemble.AppFs = fs
+
+	writeFile(t, fs, "/home/test/app/docker-compose.yml", `version: "3.8"
+services:
+  app:
+    image: php:7.4-fpm
+    container_name: app
+    working_dir: /var/www
+    user: "1000:1000"
+    restart: unless-stopped
+    environment:
+      APP_ENV: local
+    labels:
+      com.example.app: app
+    logging:
+      driver: json-file
+    depends_on:
+      - db
+      - mailhog
+    volumes:
+      - /home/test/app:/var/www
+  db:
+    image: mysql:8.0
+    restart: always
+    command: --default-authentication-plugin=mysql_native_password
+    environment:
+      MYSQL_ROOT_PASSWORD: root
+    healthcheck:
+      test: ["CMD", "mysqladmin", "ping", "-h", "localhost"]
+    cap_add:
+      - SYS_NICE
+  mailhog:
+    image: mailhog/mailhog
+`)
+
+	_, report, err := disassemble.DockerCompose("/home/test/app/docker-compose.yml")
+
+	if err != nil {
+		t.Fatalf("encountered error when disassembling correct setup: %s", err)
+	}
+
+	wantReport := disassemble.Report{
+		"service mailhog: image mailhog/mailhog is not supported",
+		"service app: user is not supported",
+		"service app: depends_on is not supported",
+		"service app: labels is not supported",
+		"service app: logging is not supported",
+		"service app: environment variable APP_ENV is not supported",
+		"service db: restart is not supported",
+		"service db: healthcheck is not supported",
+		"service db: cap_add is not supported",
+		"service db: command is not supported",
+	}
+
+	if diff := cmp.Diff(wantReport, report); diff != "" {
+		t.Errorf("DockerCompose() report mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDockerCompose_RecognizesImagesPinnedByDigest(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	disassemble.AppFs = fs
+
+	writeFile(t, fs, "/home/test/app/docker-compose.yml", `version: "3.8"
+services:
+  db:
+    image: docker.io/library/postgres:12.3@sha256:abc
+    environment:
+      POSTGRES_PASSWORD: secret
+  cache:
+    image: memcached@sha256:def
+`)
+
+	got, report, err := disassemble.DockerCompose("/home/test/app/docker-compose.yml")
+
+	if err != nil {
+		t.Fatalf("encountered error when disassembling correct setup: %s", err)
+	}
+
+	want := &service.DatabaseConfig{
+		System:      service.PostgreSQL,
+		Version:     "12.3",
+		Credentials: service.Credentials{Password: "secret"},
+	}
+
+	if diff := cmp.Diff(want, got.Services.Database); diff != "" {
+		t.Errorf("DockerCompose() database mismatch (-want +got):\n%s", diff)
+	}
+
+	wantReport := disassemble.Report{
+		"service cache: image memcached@sha256:def is not supported",
+		"project root could not be determined, directory of the compose file is used",
+	}
+
+	if diff := cmp.Diff(wantReport, report); diff != "" {
+		t.Errorf("DockerCompose() report mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDockerCompose_ReportsUnofficialImages(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	disassemble.AppFs = fs
+
+	writeFile(t, fs, "/home/test/app/docker-compose.yml", `version: "3.8"
+services:
+  app:
+    image: mycorp/php:8.1
+  cache:
+    image: registry.example.com/redis:6
+  db:
+    image: library/mysql:8.0
+`)
+
+	got, report, err := disassemble.DockerCompose("/home/test/app/docker-compose.yml")
+
+	if err != nil {
+		t.Fatalf("encountered error when disassembling correct setup: %s", err)
+	}
+
+	if got.Services.PHP != nil {
+		t.Errorf("DockerCompose() recognized mycorp/php as official php image")
+	}
+
+	if got.Services.Database == nil || got.Services.Database.System != service.MySQL {
+		t.Errorf("DockerCompose() did not recognize library/mysql as official mysql image")
+	}
+
+	wantReport := disassemble.Report{
+		"service app: image mycorp/php:8.1 is not supported",
+		"service cache: image registry.example.com/redis:6 is not supported",
+		"project root could not be determined, directory of the compose file is used",
+	}
+
+	if diff := cmp.Diff(wantReport, report); diff != "" {
+		t.Errorf("DockerCompose() report mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDockerCompose_ReadsPostgreSQLArgs(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	disassemble.AppFs = fs
+
+	writeFile(t, fs, "/home/test/app/docker-compose.yml", `version: "3.8"
+services:
+  db:
+    image: postgres:12.3
+    command: postgres -c work_mem=4MB -c "search_path='app', public" -c port=6432 --verbose
+    ports:
+      - "5432:6432"
+    environment:
+      POSTGRES_PASSWORD: secret
+`)
+
+	got, report, err := disassemble.DockerCompose("/home/test/app/docker-compose.yml")
+
+	if err != nil {
+		t.Fatalf("encountered error when disassembling correct setup: %s", err)
+	}
+
+	want := &service.DatabaseConfig{
+		System:        service.PostgreSQL,
+		Version:       "12.3",
+		Port:          5432,
+		ContainerPort: 6432,
+		Credentials:   service.Credentials{Password: "secret"},
+		Settings:      map[string]string{"work_mem": "4MB", "search_path": "'app', public"},
+	}
+
+	if diff := cmp.Diff(want, got.Services.Database); diff != "" {
+		t.Errorf("DockerCompose() database mismatch (-want +got):\n%s", diff)
+	}
+
+	wantReport := disassemble.Report{
+		"service db: command argument --verbose is not supported",
+		"project root could not be determined, directory of the compose file is used",
+	}
+
+	if diff := cmp.Diff(wantReport, report); diff != "" {
+		t.Errorf("DockerCompose() report mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDockerCompose_Errors(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	disassemble.AppFs = fs
+
+	writeFile(t, fs, "/home/test/unsupported.yml", "services:\n  cache:\n    image: memcached\n")
+
+	tests := map[string]string{
+		"missing file":         "/home/test/missing.yml",
+		"no supported service": "/home/test/unsupported.yml",
+	}
+
+	for name, path := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := disassemble.DockerCompose(path); err == nil {
+				t.Fatalf("encountered nil err when disassembling %s", path)
+			}
+		})
+	}
+}
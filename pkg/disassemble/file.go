@@ -0,0 +1,177 @@
+package disassemble
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// dockerfile is a subset of Dockerfile instructions which are relevant for the service config
+type dockerfile struct {
+	baseImage     string
+	phpExtensions []string
+}
+
+func parseDockerfile(content string) *dockerfile {
+	parsed := &dockerfile{}
+	stages := make(map[string]string)
+
+	for _, instruction := range dockerfileInstructions(content) {
+		fields := strings.Fields(instruction)
+
+		if len(fields) < 2 {
+			continue
+		}
+
+		switch strings.ToUpper(fields[0]) {
+		case "FROM":
+			// Resulting image is built from the last stage
+			parsed.baseImage = stageBaseImage(fields[1:], stages)
+		case "RUN":
+			parsed.phpExtensions = append(parsed.phpExtensions, phpExtensionsFromRun(fields[1:])...)
+		}
+	}
+
+	return parsed
+}
+
+// stageBaseImage returns the image a stage is built from, resolving earlier stages to their own base image. Stage names
+// are recorded in stages
+func stageBaseImage(args []string, stages map[string]string) string {
+	var operands []string
+
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "--") {
+			continue
+		}
+
+		operands = append(operands, arg)
+	}
+
+	if len(operands) == 0 {
+		return ""
+	}
+
+	image := operands[0]
+
+	if base, ok := stages[strings.ToLower(image)]; ok {
+		image = base
+	}
+
+	if len(operands) == 3 && strings.EqualFold(operands[1], "AS") {
+		stages[strings.ToLower(operands[2])] = image
+	}
+
+	return image
+}
+
+// dockerfileInstructions joins continuation lines and skips comments and empty lines
+func dockerfileInstructions(content string) []string {
+	var instructions []string
+	var current strings.Builder
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if strings.HasSuffix(trimmed, "\\") {
+			current.WriteString(strings.TrimSuffix(trimmed, "\\"))
+			current.WriteString(" ")
+
+			continue
+		}
+
+		current.WriteString(trimmed)
+		instructions = append(instructions, current.String())
+		current.Reset()
+	}
+
+	if current.Len() != 0 {
+		instructions = append(instructions, current.String())
+	}
+
+	return instructions
+}
+
+func phpExtensionsFromRun(args []string) []string {
+	var extensions []string
+
+	installing := false
+
+	for _, arg := range args {
+		switch {
+		case arg == "docker-php-ext-install":
+			installing = true
+		case arg == "&&" || arg == ";" || arg == "||":
+			installing = false
+		case installing && !strings.HasPrefix(arg, "-"):
+			extensions = append(extensions, strings.TrimSuffix(arg, ";"))
+		}
+	}
+
+	return extensions
+}
+
+// nginxConf is a subset of nginx server directives which are relevant for the service config
+type nginxConf struct {
+	serverName         string
+	fastCGIPassPort    int
+	fastCGIReadTimeout int
+}
+
+var (
+	serverNameDirective  = regexp.MustCompile(`(?m)^\s*server_name\s+([^\s;]+)`)
+	fastCGIPassDirective = regexp.MustCompile(`(?m)^\s*fastcgi_pass\s+[^\s;:]+:(\d+)\s*;`)
+	readTimeoutDirective = regexp.MustCompile(`(?m)^\s*fastcgi_read_timeout\s+(\d+)s?\s*;`)
+)
+
+func parseNginxConf(content string) *nginxConf {
+	parsed := &nginxConf{}
+
+	if m := serverNameDirective.FindStringSubmatch(content); m != nil {
+		parsed.serverName = strings.TrimSuffix(m[1], ".test")
+	}
+
+	if m := fastCGIPassDirective.FindStringSubmatch(content); m != nil {
+		parsed.fastCGIPassPort, _ = strconv.Atoi(m[1])
+	}
+
+	if m := readTimeoutDirective.FindStringSubmatch(content); m != nil {
+		parsed.fastCGIReadTimeout, _ = strconv.Atoi(m[1])
+	}
+
+	return parsed
+}
+
+// parseServerSettings reads "name = value" lines of my.cnf. Sections and comments are skipped, quotes around values
+// are removed
+func parseServerSettings(content string) map[string]string {
+	settings := map[string]string{}
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "[") {
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+
+		if len(parts) != 2 {
+			continue
+		}
+
+		value := strings.TrimSpace(parts[1])
+
+		if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+			value = value[1 : len(value)-1]
+		}
+
+		settings[strings.TrimSpace(parts[0])] = value
+	}
+
+	return settings
+}
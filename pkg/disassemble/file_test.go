@@ -0,0 +1,118 @@
+package disassemble
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseDockerfile(t *testing.T) {
+	content := `FROM composer:2 AS composer
+
+# Runtime image
+FROM php:7.4-fpm
+
+RUN apt-get update && apt-get install -y \
+    libzip-dev
+
+RUN docker-php-ext-install \
+    pdo_mysql \
+    zip && docker-php-ext-enable opcache
+RUN docker-php-ext-install -j$(nproc) gd
+`
+
+	want := &dockerfile{
+		baseImage:     "php:7.4-fpm",
+		phpExtensions: []string{"pdo_mysql", "zip", "gd"},
+	}
+
+	got := parseDockerfile(content)
+
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(dockerfile{})); diff != "" {
+		t.Errorf("parseDockerfile() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseDockerfile_BaseImage(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		want    string
+	}{
+		"platform flag": {
+			content: "FROM --platform=linux/amd64 php:8.1-fpm\n",
+			want:    "php:8.1-fpm",
+		},
+		"final stage built from earlier stage": {
+			content: `FROM --platform=$BUILDPLATFORM php:8.1-fpm AS base
+RUN docker-php-ext-install pdo_mysql
+
+FROM base AS dev
+RUN docker-php-ext-install zip
+
+FROM dev
+`,
+			want: "php:8.1-fpm",
+		},
+		"stage names are case-insensitive": {
+			content: "FROM php:7.4-fpm as Base\nFROM base\n",
+			want:    "php:7.4-fpm",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := parseDockerfile(tc.content).baseImage
+
+			if got != tc.want {
+				t.Errorf("parseDockerfile() base image = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseNginxConf(t *testing.T) {
+	content := `server {
+    listen 80;
+    server_name awesome.test;
+
+    location ~ \.php$ {
+        fastcgi_pass php-fpm:9001;
+        fastcgi_read_timeout 30s;
+    }
+}`
+
+	want := &nginxConf{
+		serverName:         "awesome",
+		fastCGIPassPort:    9001,
+		fastCGIReadTimeout: 30,
+	}
+
+	got := parseNginxConf(content)
+
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(nginxConf{})); diff != "" {
+		t.Errorf("parseNginxConf() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseServerSettings(t *testing.T) {
+	content := `[mysqld]
+# Tuned for development
+max_connections = 200
+sql_mode=STRICT_TRANS_TABLES,NO_ZERO_DATE
+; legacy comment
+shared_buffers = '128MB'
+skip-name-resolve
+`
+
+	want := map[string]string{
+		"max_connections": "200",
+		"sql_mode":        "STRICT_TRANS_TABLES,NO_ZERO_DATE",
+		"shared_buffers":  "128MB",
+	}
+
+	got := parseServerSettings(content)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("parseServerSettings() mismatch (-want +got):\n%s", diff)
+	}
+}
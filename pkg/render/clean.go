@@ -0,0 +1,65 @@
+package render
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/Bocmah/phpdocker-gen/pkg/service"
+)
+
+// Clean removes files which were rendered from conf together with their manifest. Directories which become empty
+// are removed up to the output path of conf. Paths of the removed files are returned even if an error occurs. Files
+// modified by hand are removed as well, so check ModifiedFiles first to keep the changes
+func Clean(conf *service.FullConfig) ([]string, error) {
+	var removed []string
+
+	for _, path := range append(renderedPaths(conf), ManifestPath(conf)) {
+		if !pathExists(AppFs, path) {
+			continue
+		}
+
+		if removeErr := AppFs.Remove(path); removeErr != nil {
+			return removed, fmt.Errorf("remove file: %s", removeErr)
+		}
+
+		removed = append(removed, path)
+
+		if removeErr := removeEmptyDirs(filepath.Dir(path), conf.GetOutputPath()); removeErr != nil {
+			return removed, removeErr
+		}
+	}
+
+	return removed, nil
+}
+
+// removeEmptyDirs removes dir and its parents while they are empty and located inside root (root included)
+func removeEmptyDirs(dir, root string) error {
+	for {
+		if rel, relErr := filepath.Rel(root, dir); relErr != nil || strings.HasPrefix(rel, "..") {
+			return nil
+		}
+
+		empty, emptyErr := afero.IsEmpty(AppFs, dir)
+
+		if emptyErr != nil {
+			return fmt.Errorf("check dir: %s", emptyErr)
+		}
+
+		if !empty {
+			return nil
+		}
+
+		if removeErr := AppFs.Remove(dir); removeErr != nil {
+			return fmt.Errorf("remove dir: %s", removeErr)
+		}
+
+		if filepath.Clean(dir) == filepath.Clean(root) {
+			return nil
+		}
+
+		dir = filepath.Dir(dir)
+	}
+}
@@ -0,0 +1,119 @@
+package render_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+
+	"github.com/Bocmah/phpdocker-gen/pkg/render"
+	"github.com/Bocmah/phpdocker-gen/pkg/service"
+)
+
+func TestClean(t *testing.T) {
+	render.AppFs = afero.NewMemMapFs()
+
+	conf := &service.FullConfig{
+		AppName:     "awesome-app",
+		ProjectRoot: "/home/test/app",
+		Services: &service.ServicesConfig{
+			PHP:    &service.PHPConfig{Version: "7.4"},
+			Nginx:  &service.NginxConfig{ServerName: "awesomeapp"},
+			NodeJS: &service.NodeJSConfig{Version: "10"},
+		},
+	}
+
+	createDir(t, render.AppFs, "/home/test/app/.docker/php")
+	createDir(t, render.AppFs, "/home/test/app/.docker/nginx/conf.d")
+	createFile(t, render.AppFs, "/home/test/app/.docker/php/Dockerfile")
+	createFile(t, render.AppFs, "/home/test/app/.docker/nginx/conf.d/app.conf")
+	createFile(t, render.AppFs, "/home/test/app/.docker/nginx/custom.conf")
+	createFile(t, render.AppFs, "/home/test/app/.docker/docker-compose.yml")
+	createFile(t, render.AppFs, "/home/test/app/index.php")
+
+	removed, err := render.Clean(conf)
+
+	if err != nil {
+		t.Fatalf("render.Clean() returned error: %s", err)
+	}
+
+	want := []string{
+		"/home/test/app/.docker/docker-compose.yml",
+		"/home/test/app/.docker/nginx/conf.d/app.conf",
+		"/home/test/app/.docker/php/Dockerfile",
+	}
+
+	if diff := cmp.Diff(want, removed); diff != "" {
+		t.Errorf("render.Clean() mismatch (-want +got):\n%s", diff)
+	}
+
+	checkFileDoesntExist(t, render.AppFs, "/home/test/app/.docker/php")
+	checkFileDoesntExist(t, render.AppFs, "/home/test/app/.docker/nginx/conf.d")
+
+	for _, kept := range []string{"/home/test/app/.docker/nginx/custom.conf", "/home/test/app/index.php"} {
+		if exists, _ := afero.Exists(render.AppFs, kept); !exists {
+			t.Errorf("file %s which was not generated was removed", kept)
+		}
+	}
+
+	removed, err = render.Clean(conf)
+
+	if err != nil || len(removed) != 0 {
+		t.Errorf("second render.Clean() got %v, %v, want nothing removed", removed, err)
+	}
+}
+
+func TestClean_RemovesEmptyOutputPath(t *testing.T) {
+	render.AppFs = afero.NewMemMapFs()
+
+	conf := &service.FullConfig{
+		AppName:     "awesome-app",
+		ProjectRoot: "/home/test/app",
+		Services: &service.ServicesConfig{
+			PHP: &service.PHPConfig{Version: "7.4"},
+		},
+	}
+
+	createDir(t, render.AppFs, "/home/test/app/.docker/php")
+	createFile(t, render.AppFs, "/home/test/app/.docker/php/Dockerfile")
+	createFile(t, render.AppFs, "/home/test/app/.docker/docker-compose.yml")
+
+	if _, err := render.Clean(conf); err != nil {
+		t.Fatalf("render.Clean() returned error: %s", err)
+	}
+
+	checkFileDoesntExist(t, render.AppFs, "/home/test/app/.docker")
+
+	if exists, _ := afero.DirExists(render.AppFs, "/home/test/app"); !exists {
+		t.Errorf("project root was removed")
+	}
+}
+
+func createDir(t *testing.T, fs afero.Fs, path string) {
+	t.Helper()
+
+	mkdirAllErr := fs.MkdirAll(path, 0755)
+
+	if mkdirAllErr != nil {
+		t.Fatalf("Failed to create dir %s, err %s", path, mkdirAllErr)
+	}
+}
+
+func createFile(t *testing.T, fs afero.Fs, path string) {
+	t.Helper()
+
+	writeFileErr := afero.WriteFile(fs, path, []byte("test"), 0644)
+
+	if writeFileErr != nil {
+		t.Fatalf("Failed to write file %s, err %s", path, writeFileErr)
+	}
+}
+
+func checkFileDoesntExist(t *testing.T, fs afero.Fs, path string) {
+	t.Helper()
+
+	if _, err := fs.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("File %s exists when it shouldn't", path)
+	}
+}
@@ -0,0 +1,56 @@
+package render
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TemplateError is an error in the template which prevents rendering
+type TemplateError struct {
+	// Path is the path of the embedded template or the file which overrides it
+	Path string
+	// Line and Column point to the place of the error in the template. They are 0 if position is unknown
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *TemplateError) Error() string {
+	switch {
+	case e.Line != 0 && e.Column != 0:
+		return fmt.Sprintf("template %s:%d:%d: %s", e.Path, e.Line, e.Column, e.Message)
+	case e.Line != 0:
+		return fmt.Sprintf("template %s:%d: %s", e.Path, e.Line, e.Message)
+	default:
+		return fmt.Sprintf("template %s: %s", e.Path, e.Message)
+	}
+}
+
+// templatePosition matches position which text/template puts in front of parse ("12: ...") and execution
+// ("12:5: ...") errors after the template name
+var templatePosition = regexp.MustCompile(`^(\d+)(?::(\d+))?: `)
+
+// newTemplateError creates TemplateError from error which text/template returned for template named path
+func newTemplateError(path string, err error) *TemplateError {
+	templateErr := &TemplateError{Path: path, Message: err.Error()}
+
+	rest := strings.TrimPrefix(err.Error(), "template: "+path+":")
+
+	if rest == err.Error() {
+		return templateErr
+	}
+
+	position := templatePosition.FindStringSubmatch(rest)
+
+	if position == nil {
+		return templateErr
+	}
+
+	templateErr.Line, _ = strconv.Atoi(position[1])
+	templateErr.Column, _ = strconv.Atoi(position[2])
+	templateErr.Message = rest[len(position[0]):]
+
+	return templateErr
+}
@@ -0,0 +1,123 @@
+package render
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+
+	"github.com/Bocmah/phpdocker-gen/pkg/service"
+)
+
+// ManifestFileName is the name of the file inside output path which holds checksums of generated files
+const ManifestFileName = ".phpdocker-gen-manifest.yaml"
+
+const manifestHeader = "# Checksums of files generated by phpdocker-gen. Do not edit\n"
+
+// Manifest maps paths of generated files (relative to the output path) to SHA-256 checksums of their contents
+type Manifest struct {
+	Files map[string]string `yaml:"files"`
+}
+
+// ManifestPath returns path to the manifest of files generated from conf
+func ManifestPath(conf *service.FullConfig) string {
+	return filepath.Join(conf.GetOutputPath(), ManifestFileName)
+}
+
+// LoadManifest reads manifest of files generated from conf. Empty manifest is returned if nothing was recorded yet
+func LoadManifest(conf *service.FullConfig) (*Manifest, error) {
+	manifest := &Manifest{Files: map[string]string{}}
+
+	data, readErr := afero.ReadFile(AppFs, ManifestPath(conf))
+
+	if os.IsNotExist(readErr) {
+		return manifest, nil
+	}
+
+	if readErr != nil {
+		return nil, fmt.Errorf("read manifest: %s", readErr)
+	}
+
+	if unmarshalErr := yaml.Unmarshal(data, manifest); unmarshalErr != nil {
+		return nil, fmt.Errorf("parse manifest: %s", unmarshalErr)
+	}
+
+	if manifest.Files == nil {
+		manifest.Files = map[string]string{}
+	}
+
+	return manifest, nil
+}
+
+// manifestFile creates manifest file with checksums of files rendered from conf
+func manifestFile(conf *service.FullConfig, files []*File) (*File, error) {
+	manifest := &Manifest{Files: map[string]string{}}
+
+	for _, file := range files {
+		manifest.Files[relativeToOutput(conf, file.Path)] = checksum(file.Content)
+	}
+
+	data, marshalErr := yaml.Marshal(manifest)
+
+	if marshalErr != nil {
+		return nil, fmt.Errorf("marshal manifest: %s", marshalErr)
+	}
+
+	return &File{Path: ManifestPath(conf), Content: append([]byte(manifestHeader), data...)}, nil
+}
+
+// ModifiedFiles returns sorted paths of files rendered from conf which were changed since they were recorded in the
+// manifest. Files which are missing or were not recorded are not considered modified
+func ModifiedFiles(conf *service.FullConfig) ([]string, error) {
+	manifest, loadErr := LoadManifest(conf)
+
+	if loadErr != nil {
+		return nil, loadErr
+	}
+
+	var modified []string
+
+	for _, path := range renderedPaths(conf) {
+		recorded, ok := manifest.Files[relativeToOutput(conf, path)]
+
+		if !ok {
+			continue
+		}
+
+		content, readErr := afero.ReadFile(AppFs, path)
+
+		if os.IsNotExist(readErr) {
+			continue
+		}
+
+		if readErr != nil {
+			return nil, fmt.Errorf("read rendered file: %s", readErr)
+		}
+
+		if checksum(content) != recorded {
+			modified = append(modified, path)
+		}
+	}
+
+	return modified, nil
+}
+
+func relativeToOutput(conf *service.FullConfig, path string) string {
+	rel, relErr := filepath.Rel(conf.GetOutputPath(), path)
+
+	if relErr != nil {
+		return path
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,146 @@
+package render_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+
+	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
+	"github.com/Bocmah/phpdocker-gen/pkg/render"
+	"github.com/Bocmah/phpdocker-gen/pkg/service"
+)
+
+func createManifestTestConf(t *testing.T) *service.FullConfig {
+	t.Helper()
+
+	render.AppFs = afero.NewMemMapFs()
+
+	conf := &service.FullConfig{
+		AppName:     "awesome-app",
+		ProjectRoot: "/home/test/app",
+		Services: &service.ServicesConfig{
+			PHP:   &service.PHPConfig{Version: "7.4"},
+			Nginx: &service.NginxConfig{ServerName: "awesomeapp"},
+		},
+	}
+
+	conf.FillDefaultsIfNotSet()
+
+	return conf
+}
+
+func generate(t *testing.T, conf *service.FullConfig) {
+	t.Helper()
+
+	if err := render.Generate(conf, &dockercompose.Config{Version: "3.8"}); err != nil {
+		t.Fatalf("render.Generate() returned error: %s", err)
+	}
+}
+
+func fileChecksum(t *testing.T, path string) string {
+	t.Helper()
+
+	content, readErr := afero.ReadFile(render.AppFs, path)
+
+	if readErr != nil {
+		t.Fatalf("failed to read %s: %s", path, readErr)
+	}
+
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:])
+}
+
+func TestGenerate_WritesManifest(t *testing.T) {
+	conf := createManifestTestConf(t)
+
+	generate(t, conf)
+
+	data, readErr := afero.ReadFile(render.AppFs, "/home/test/app/.docker/"+render.ManifestFileName)
+
+	if readErr != nil {
+		t.Fatalf("manifest was not written: %s", readErr)
+	}
+
+	if !strings.HasPrefix(string(data), "# ") {
+		t.Errorf("manifest does not start with a header comment:\n%s", data)
+	}
+
+	manifest, loadErr := render.LoadManifest(conf)
+
+	if loadErr != nil {
+		t.Fatalf("render.LoadManifest() returned error: %s", loadErr)
+	}
+
+	want := &render.Manifest{
+		Files: map[string]string{
+			"docker-compose.yml":    fileChecksum(t, "/home/test/app/.docker/docker-compose.yml"),
+			"nginx/conf.d/app.conf": fileChecksum(t, "/home/test/app/.docker/nginx/conf.d/app.conf"),
+			"php/Dockerfile":        fileChecksum(t, "/home/test/app/.docker/php/Dockerfile"),
+		},
+	}
+
+	if diff := cmp.Diff(want, manifest); diff != "" {
+		t.Errorf("render.LoadManifest() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoadManifest_Missing(t *testing.T) {
+	conf := createManifestTestConf(t)
+
+	manifest, err := render.LoadManifest(conf)
+
+	if err != nil {
+		t.Fatalf("render.LoadManifest() returned error: %s", err)
+	}
+
+	if diff := cmp.Diff(&render.Manifest{Files: map[string]string{}}, manifest); diff != "" {
+		t.Errorf("render.LoadManifest() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestModifiedFiles(t *testing.T) {
+	conf := createManifestTestConf(t)
+
+	modified, err := render.ModifiedFiles(conf)
+
+	if err != nil || len(modified) != 0 {
+		t.Fatalf("render.ModifiedFiles() without manifest got %v, %v, want nothing", modified, err)
+	}
+
+	generate(t, conf)
+
+	if err := afero.WriteFile(render.AppFs, "/home/test/app/.docker/nginx/conf.d/app.conf", []byte("edited"), 0644); err != nil {
+		t.Fatalf("failed to edit file: %s", err)
+	}
+
+	if err := render.AppFs.Remove("/home/test/app/.docker/php/Dockerfile"); err != nil {
+		t.Fatalf("failed to remove file: %s", err)
+	}
+
+	modified, err = render.ModifiedFiles(conf)
+
+	if err != nil {
+		t.Fatalf("render.ModifiedFiles() returned error: %s", err)
+	}
+
+	if diff := cmp.Diff([]string{"/home/test/app/.docker/nginx/conf.d/app.conf"}, modified); diff != "" {
+		t.Errorf("render.ModifiedFiles() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestClean_RemovesManifest(t *testing.T) {
+	conf := createManifestTestConf(t)
+
+	generate(t, conf)
+
+	if _, err := render.Clean(conf); err != nil {
+		t.Fatalf("render.Clean() returned error: %s", err)
+	}
+
+	checkFileDoesntExist(t, render.AppFs, "/home/test/app/.docker")
+}
@@ -2,6 +2,7 @@ package render
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/spf13/afero"
 
@@ -13,30 +14,74 @@ import (
 // AppFs is the filesystem in use
 var AppFs = afero.NewOsFs()
 
-// RenderServices renders files for all services from service.FullConfig
-func RenderServices(conf *service.FullConfig) (*RenderedServices, error) {
-	renderedServices := RenderedServices{
-		Services: map[service.SupportedService][]*Rendered{},
+// File is a file rendered in memory
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// RenderToMemory renders files for all services from conf and docker-compose file from compose without touching
+// AppFs. Files are sorted by path
+func RenderToMemory(conf *service.FullConfig, compose *dockercompose.Config) ([]*File, error) {
+	fs := afero.NewMemMapFs()
+
+	if renderErr := renderServices(fs, conf); renderErr != nil {
+		return nil, renderErr
+	}
+
+	if renderErr := renderDockerCompose(fs, compose, conf.GetDockerComposePath()); renderErr != nil {
+		return nil, renderErr
+	}
+
+	paths := renderedPaths(conf)
+	files := make([]*File, 0, len(paths))
+
+	for _, path := range paths {
+		content, readErr := afero.ReadFile(fs, path)
+
+		if readErr != nil {
+			return nil, fmt.Errorf("read rendered file: %s", readErr)
+		}
+
+		files = append(files, &File{Path: path, Content: content})
 	}
 
-	for serv, renderableFiles := range conf.GetServiceFiles() {
+	return files, nil
+}
+
+// renderedPaths returns sorted paths of all files which are rendered from conf
+func renderedPaths(conf *service.FullConfig) []string {
+	paths := []string{conf.GetDockerComposePath()}
+
+	for _, renderableFiles := range conf.GetServiceFiles() {
 		for _, file := range renderableFiles {
-			rendered, renderErr := render(file, conf)
+			paths = append(paths, file.GetOutputPath())
+		}
+	}
 
-			if renderErr != nil {
-				return nil, fmt.Errorf("render services: %s", renderErr)
-			}
+	sort.Strings(paths)
 
-			renderedServices.Services[serv] = append(renderedServices.Services[serv], rendered)
+	return paths
+}
+
+func renderServices(fs afero.Fs, conf *service.FullConfig) error {
+	for _, renderableFiles := range conf.GetServiceFiles() {
+		for _, file := range renderableFiles {
+			if renderErr := render(fs, file, conf); renderErr != nil {
+				if _, ok := renderErr.(*TemplateError); ok {
+					return renderErr
+				}
+
+				return fmt.Errorf("render services: %s", renderErr)
+			}
 		}
 	}
 
-	return &renderedServices, nil
+	return nil
 }
 
-// RenderDockerCompose renders docker-compose.yml file
-func RenderDockerCompose(conf *dockercompose.Config, outputPath string) error {
-	file, createErr := AppFs.Create(outputPath)
+func renderDockerCompose(fs afero.Fs, conf *dockercompose.Config, outputPath string) error {
+	file, createErr := fs.Create(outputPath)
 
 	if createErr != nil {
 		return fmt.Errorf("create output file: %s", createErr)
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/spf13/afero"
@@ -20,11 +21,6 @@ import (
 	"github.com/Bocmah/phpdocker-gen/pkg/service"
 )
 
-type renderedServicesWithFs struct {
-	services *render.RenderedServices
-	fs       afero.Fs
-}
-
 func TestRenderTemplatesFromConfiguration(t *testing.T) {
 	render.AppFs = afero.NewMemMapFs()
 
@@ -74,7 +70,7 @@ func TestRenderTemplatesFromConfiguration(t *testing.T) {
 		},
 	}
 
-	rendered, renderErr := render.RenderServices(conf)
+	renderErr := render.Generate(conf, &dockercompose.Config{Version: "3.8"})
 
 	if renderErr != nil {
 		t.Fatalf("Encountered non-nil error in correct test case: %v", renderErr)
@@ -84,56 +80,21 @@ func TestRenderTemplatesFromConfiguration(t *testing.T) {
 		t.Fatalf("Output path %v still doesn't exist after calling rendering function", outputPath)
 	}
 
-	wantRendered := &render.RenderedServices{
-		Services: map[service.SupportedService][]*render.Rendered{
-			service.PHP: {
-				{
-					Path:        filepath.Join(outputPath, "php/Dockerfile"),
-					CreatedDirs: []string{filepath.Join(outputPath, "php")},
-				},
-			},
-			service.Nginx: {
-				{
-					Path:        filepath.Join(outputPath, "nginx/conf.d/app.conf"),
-					CreatedDirs: []string{filepath.Join(outputPath, "nginx/conf.d")},
-				},
-			},
-			service.NodeJS: {
-				{
-					Path:        filepath.Join(outputPath, "nodejs/Dockerfile"),
-					CreatedDirs: []string{filepath.Join(outputPath, "nodejs")},
-				},
-			},
-		},
-	}
-
-	if diff := cmp.Diff(wantRendered, rendered); diff != "" {
-		t.Fatalf("RenderedServices mismatch (-want +got):\n%s", diff)
-	}
-
 	const testFilesRoot = "testdata/template_render/.docker"
-	testFiles := map[service.SupportedService][]string{
-		service.PHP:    {filepath.Join(testFilesRoot, "php/Dockerfile")},
-		service.Nginx:  {filepath.Join(testFilesRoot, "nginx/conf.d/app.conf")},
-		service.NodeJS: {filepath.Join(testFilesRoot, "nodejs/Dockerfile")},
+	testFiles := map[string]string{
+		filepath.Join(outputPath, "php/Dockerfile"):        filepath.Join(testFilesRoot, "php/Dockerfile"),
+		filepath.Join(outputPath, "nginx/conf.d/app.conf"): filepath.Join(testFilesRoot, "nginx/conf.d/app.conf"),
+		filepath.Join(outputPath, "nodejs/Dockerfile"):     filepath.Join(testFilesRoot, "nodejs/Dockerfile"),
 	}
 
-	if diff := compareRenderedWithExpected(&renderedServicesWithFs{services: rendered, fs: render.AppFs}, testFiles); diff != "" {
+	if diff := compareRenderedWithExpected(render.AppFs, testFiles); diff != "" {
 		t.Fatalf(diff)
 	}
 }
 
 func TestRenderDockerCompose(t *testing.T) {
-	render.AppFs = afero.NewMemMapFs()
-
 	const testDir = "output"
 
-	mkdirErr := render.AppFs.Mkdir(testDir, 0755)
-
-	if mkdirErr != nil {
-		t.Fatalf("failed to create test dir: %s", mkdirErr)
-	}
-
 	network := &dockercompose.Network{Name: "awesome-app-network", Driver: dockercompose.NetworkDriverBridge}
 	namedVolume := &dockercompose.NamedVolume{Name: "awesome-app-data", Driver: dockercompose.VolumeDriverLocal}
 	projectRoot := "/home/test/app"
@@ -156,7 +117,7 @@ func TestRenderDockerCompose(t *testing.T) {
 				},
 				WorkingDir: workDir,
 				Restart:    dockercompose.RestartPolicyUnlessStopped,
-				Networks:   dockercompose.ServiceNetworks{network},
+				Networks:   dockercompose.ServiceNetworks{{Network: network}},
 				Volumes: dockercompose.ServiceVolumes{
 					&dockercompose.ServiceVolume{Source: projectRoot, Target: workDir},
 				},
@@ -169,7 +130,7 @@ func TestRenderDockerCompose(t *testing.T) {
 					&dockercompose.PortsMapping{Host: 80, Container: 80},
 					&dockercompose.PortsMapping{Host: 443, Container: 443},
 				},
-				Networks: dockercompose.ServiceNetworks{network},
+				Networks: dockercompose.ServiceNetworks{{Network: network}},
 				Volumes: dockercompose.ServiceVolumes{
 					&dockercompose.ServiceVolume{Source: projectRoot, Target: workDir},
 					&dockercompose.ServiceVolume{Source: "./nginx/conf.d/", Target: "/etc/nginx/conf.d/"},
@@ -189,7 +150,7 @@ func TestRenderDockerCompose(t *testing.T) {
 					"MYSQL_USER":          "test-user",
 					"MYSQL_PASSWORD":      "secret-password",
 				},
-				Networks: dockercompose.ServiceNetworks{network},
+				Networks: dockercompose.ServiceNetworks{{Network: network}},
 				Volumes: dockercompose.ServiceVolumes{
 					&dockercompose.ServiceVolume{Source: namedVolume.Name, Target: "/var/lib/mysql"},
 				},
@@ -202,7 +163,7 @@ func TestRenderDockerCompose(t *testing.T) {
 					Dockerfile: filepath.Join(projectRoot, ".docker/nodejs/Dockerfile"),
 				},
 				WorkingDir: "/opt",
-				Networks:   dockercompose.ServiceNetworks{network},
+				Networks:   dockercompose.ServiceNetworks{{Network: network}},
 				Volumes: dockercompose.ServiceVolumes{
 					&dockercompose.ServiceVolume{Source: projectRoot, Target: "/opt"},
 				},
@@ -210,18 +171,24 @@ func TestRenderDockerCompose(t *testing.T) {
 		},
 	}
 
-	outputPath := filepath.Join(testDir, "docker-compose.yml")
+	fullConf := &service.FullConfig{OutputPath: testDir, Services: &service.ServicesConfig{}}
 
-	renderErr := render.RenderDockerCompose(conf, outputPath)
+	files, renderErr := render.RenderToMemory(fullConf, conf)
 
 	if renderErr != nil {
 		t.Fatalf("encountered non nil err with correct configuration: %s", renderErr)
 	}
 
-	data, readErr := afero.ReadFile(render.AppFs, outputPath)
+	var data []byte
 
-	if readErr != nil {
-		t.Fatalf("failed to read resulting docker-compose.yml: %s", readErr)
+	for _, file := range files {
+		if file.Path == fullConf.GetDockerComposePath() {
+			data = file.Content
+		}
+	}
+
+	if data == nil {
+		t.Fatalf("docker-compose.yml was not rendered")
 	}
 
 	want := map[string]interface{}{
@@ -314,32 +281,241 @@ func TestRenderDockerCompose(t *testing.T) {
 	}
 }
 
-func compareRenderedWithExpected(renderedServicesWithFs *renderedServicesWithFs, testFiles map[service.SupportedService][]string) (diff string) {
-	for serv, files := range testFiles {
-		renderedService, ok := renderedServicesWithFs.services.Services[serv]
+func TestRenderToMemory(t *testing.T) {
+	render.AppFs = afero.NewMemMapFs()
 
-		if !ok {
-			return fmt.Sprintf("Service %s was not rendered", serv)
-		}
+	conf := &service.FullConfig{
+		AppName:     "awesome-app",
+		ProjectRoot: "/home/test/app",
+		OutputPath:  "/home/test/app/.docker",
+		Services: &service.ServicesConfig{
+			PHP: &service.PHPConfig{
+				Version:    "7.4",
+				Extensions: []string{"mbstring", "exif", "pdo_mysql"},
+			},
+			Nginx: &service.NginxConfig{
+				HTTPPort:   80,
+				HTTPSPort:  443,
+				ServerName: "awesomeapp",
+				FastCGI: &service.FastCGI{
+					PassPort:           9000,
+					ReadTimeoutSeconds: 60,
+				},
+			},
+		},
+	}
 
-		for index, file := range files {
-			expectedFile, readExpectedErr := ioutil.ReadFile(file)
+	compose := &dockercompose.Config{Version: "3.8"}
 
-			if readExpectedErr != nil {
-				return fmt.Sprintf("Could not read expected file for service %s at path %s. Reason: %s", serv, file, readExpectedErr)
-			}
+	files, renderErr := render.RenderToMemory(conf, compose)
+
+	if renderErr != nil {
+		t.Fatalf("encountered non nil err with correct configuration: %s", renderErr)
+	}
 
-			renderedFile, readRenderedErr := afero.ReadFile(renderedServicesWithFs.fs, renderedService[index].Path)
+	const testFilesRoot = "testdata/template_render/.docker"
 
-			if readRenderedErr != nil {
-				return fmt.Sprintf("Could not read expected file for service %s at path %s. Reason: %s", serv, renderedService[index].Path, readRenderedErr)
-			}
+	want := []string{
+		"/home/test/app/.docker/docker-compose.yml",
+		"/home/test/app/.docker/nginx/conf.d/app.conf",
+		"/home/test/app/.docker/php/Dockerfile",
+	}
+	expected := map[string]string{
+		"/home/test/app/.docker/docker-compose.yml":    "",
+		"/home/test/app/.docker/nginx/conf.d/app.conf": filepath.Join(testFilesRoot, "nginx/conf.d/app.conf"),
+		"/home/test/app/.docker/php/Dockerfile":        filepath.Join(testFilesRoot, "php/Dockerfile"),
+	}
+
+	var got []string
 
-			if diff := cmp.Diff(expectedFile, renderedFile); diff != "" {
-				return fmt.Sprintf("Expected and rendered file for service %s mismatch (-want +got):\n%s", serv, diff)
+	for _, file := range files {
+		got = append(got, file.Path)
+
+		if expected[file.Path] == "" {
+			if diff := cmp.Diff(compose.Render(), string(file.Content)); diff != "" {
+				t.Errorf("%s mismatch (-want +got):\n%s", file.Path, diff)
 			}
+
+			continue
+		}
+
+		testFile, readErr := ioutil.ReadFile(expected[file.Path])
+
+		if readErr != nil {
+			t.Fatalf("failed to read test file: %s", readErr)
+		}
+
+		if diff := cmp.Diff(string(testFile), string(file.Content)); diff != "" {
+			t.Errorf("%s mismatch (-want +got):\n%s", file.Path, diff)
+		}
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("rendered paths mismatch (-want +got):\n%s", diff)
+	}
+
+	if exists, _ := afero.DirExists(render.AppFs, conf.OutputPath); exists {
+		t.Errorf("RenderToMemory() wrote to AppFs")
+	}
+}
+
+func compareRenderedWithExpected(fs afero.Fs, testFiles map[string]string) (diff string) {
+	for renderedPath, expectedPath := range testFiles {
+		expectedFile, readExpectedErr := ioutil.ReadFile(expectedPath)
+
+		if readExpectedErr != nil {
+			return fmt.Sprintf("Could not read expected file at path %s. Reason: %s", expectedPath, readExpectedErr)
+		}
+
+		renderedFile, readRenderedErr := afero.ReadFile(fs, renderedPath)
+
+		if readRenderedErr != nil {
+			return fmt.Sprintf("Could not read rendered file at path %s. Reason: %s", renderedPath, readRenderedErr)
+		}
+
+		if diff := cmp.Diff(expectedFile, renderedFile); diff != "" {
+			return fmt.Sprintf("Expected and rendered file %s mismatch (-want +got):\n%s", renderedPath, diff)
 		}
 	}
 
 	return ""
 }
+
+func TestRenderToMemory_TemplatesDir(t *testing.T) {
+	render.AppFs = afero.NewMemMapFs()
+
+	const override = "FROM php:{{ .Services.PHP.Version }}-custom\n"
+
+	if err := afero.WriteFile(render.AppFs, "/home/test/templates/php/php.dockerfile.gotmpl", []byte(override), 0644); err != nil {
+		t.Fatalf("failed to write template: %s", err)
+	}
+
+	conf := &service.FullConfig{
+		AppName:      "awesome-app",
+		ProjectRoot:  "/home/test/app",
+		TemplatesDir: "/home/test/templates",
+		Services: &service.ServicesConfig{
+			PHP:    &service.PHPConfig{Version: "7.4"},
+			NodeJS: &service.NodeJSConfig{Version: "10"},
+		},
+	}
+
+	files, renderErr := render.RenderToMemory(conf, &dockercompose.Config{Version: "3.8"})
+
+	if renderErr != nil {
+		t.Fatalf("encountered non nil err with correct configuration: %s", renderErr)
+	}
+
+	got := map[string]string{}
+
+	for _, file := range files {
+		got[file.Path] = string(file.Content)
+	}
+
+	if diff := cmp.Diff("FROM php:7.4-custom\n", got["/home/test/app/.docker/php/Dockerfile"]); diff != "" {
+		t.Errorf("overridden template was not used (-want +got):\n%s", diff)
+	}
+
+	embedded, readErr := ioutil.ReadFile("testdata/template_render/.docker/nodejs/Dockerfile")
+
+	if readErr != nil {
+		t.Fatalf("failed to read test file: %s", readErr)
+	}
+
+	if diff := cmp.Diff(string(embedded), got["/home/test/app/.docker/nodejs/Dockerfile"]); diff != "" {
+		t.Errorf("embedded template was not used for not overridden file (-want +got):\n%s", diff)
+	}
+}
+
+func TestRenderToMemory_PECLExtensions(t *testing.T) {
+	render.AppFs = afero.NewMemMapFs()
+
+	conf := &service.FullConfig{
+		AppName:     "awesome-app",
+		ProjectRoot: "/home/test/app",
+		Services: &service.ServicesConfig{
+			PHP:   &service.PHPConfig{Version: "7.4", Extensions: []string{"mbstring", "redis", "xdebug"}},
+			Nginx: &service.NginxConfig{FastCGI: &service.FastCGI{PassPort: 9000}},
+		},
+	}
+
+	files, renderErr := render.RenderToMemory(conf, &dockercompose.Config{Version: "3.8"})
+
+	if renderErr != nil {
+		t.Fatalf("encountered non nil err with correct configuration: %s", renderErr)
+	}
+
+	want := `# Install and enable extensions
+RUN docker-php-ext-install \
+    mbstring
+
+RUN pecl install redis-5.3.7 xdebug-3.1.6 \
+    && docker-php-ext-enable redis xdebug
+
+# Install composer`
+
+	for _, file := range files {
+		if file.Path != "/home/test/app/.docker/php/Dockerfile" {
+			continue
+		}
+
+		if !strings.Contains(string(file.Content), want) {
+			t.Errorf("Dockerfile does not install PECL extensions:\n%s", file.Content)
+		}
+
+		return
+	}
+
+	t.Errorf("Dockerfile was not rendered")
+}
+
+func TestRenderToMemory_DatabaseSettings(t *testing.T) {
+	render.AppFs = afero.NewMemMapFs()
+
+	conf := &service.FullConfig{
+		AppName:     "awesome-app",
+		ProjectRoot: "/home/test/app",
+		Services: &service.ServicesConfig{
+			Databases: []*service.DatabaseConfig{
+				{
+					System: service.MySQL,
+					Settings: map[string]string{
+						"sql_mode":                "STRICT_TRANS_TABLES",
+						"innodb_buffer_pool_size": "256M",
+					},
+				},
+				{
+					Service:       "analytics",
+					System:        service.PostgreSQL,
+					ContainerPort: 6432,
+					Settings:      map[string]string{"max_connections": "200"},
+				},
+			},
+		},
+	}
+
+	files, renderErr := render.RenderToMemory(conf, &dockercompose.Config{Version: "3.8"})
+
+	if renderErr != nil {
+		t.Fatalf("encountered non nil err with correct configuration: %s", renderErr)
+	}
+
+	want := map[string]string{
+		"/home/test/app/.docker/db/my.cnf": `[mysqld]
+innodb_buffer_pool_size = 256M
+sql_mode = STRICT_TRANS_TABLES
+`,
+	}
+
+	got := map[string]string{}
+
+	for _, file := range files {
+		if file.Path != conf.GetDockerComposePath() {
+			got[file.Path] = string(file.Content)
+		}
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("RenderToMemory() mismatch (-want +got):\n%s", diff)
+	}
+}
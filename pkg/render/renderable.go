@@ -1,11 +1,14 @@
 package render
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 	"text/template"
 
+	"github.com/spf13/afero"
+
 	"github.com/Bocmah/phpdocker-gen/internal/box"
 
 	"github.com/Bocmah/phpdocker-gen/pkg/service"
@@ -17,44 +20,88 @@ type RenderableFile interface {
 	GetTemplatePath() string
 	// GetOutputPath returns a path to which resulting file should be rendered
 	GetOutputPath() string
+	// GetTemplateData returns data with which template is executed. Config is used if it is nil
+	GetTemplateData() interface{}
 }
 
-func render(renderable RenderableFile, conf *service.FullConfig) (*Rendered, error) {
-	rendered := &Rendered{}
+func render(fs afero.Fs, renderable RenderableFile, conf *service.FullConfig) error {
+	var data interface{} = conf
 
-	tmpl := string(box.Get(renderable.GetTemplatePath()))
+	if d := renderable.GetTemplateData(); d != nil {
+		data = d
+	}
 
-	parsedTmpl := template.Must(template.New("").Parse(tmpl))
+	content, renderErr := execute(renderable.GetTemplatePath(), conf, data)
+
+	if renderErr != nil {
+		return renderErr
+	}
 
 	outputDir := filepath.Dir(renderable.GetOutputPath())
 
-	if !pathExists(outputDir) {
-		if mkdirErr := AppFs.MkdirAll(outputDir, 0755); mkdirErr != nil {
-			return nil, fmt.Errorf("MkdirAll: %s", mkdirErr)
+	if !pathExists(fs, outputDir) {
+		if mkdirErr := fs.MkdirAll(outputDir, 0755); mkdirErr != nil {
+			return fmt.Errorf("MkdirAll: %s", mkdirErr)
 		}
+	}
 
-		rendered.CreatedDirs = append(rendered.CreatedDirs, outputDir)
+	if writeErr := afero.WriteFile(fs, renderable.GetOutputPath(), content, 0644); writeErr != nil {
+		return fmt.Errorf("write output file: %s", writeErr)
 	}
 
-	file, createFileErr := AppFs.Create(renderable.GetOutputPath())
+	return nil
+}
+
+// execute renders template at path with data. Templates dir is taken from conf. Template errors are reported as
+// *TemplateError
+func execute(path string, conf *service.FullConfig, data interface{}) ([]byte, error) {
+	source, tmpl, loadErr := loadTemplate(path, conf)
 
-	if createFileErr != nil {
-		return nil, fmt.Errorf("create output file: %s", createFileErr)
+	if loadErr != nil {
+		return nil, loadErr
 	}
 
-	defer file.Close()
+	if tmpl == nil {
+		return nil, &TemplateError{Path: source, Message: "template not found"}
+	}
 
-	if executeErr := parsedTmpl.Execute(file, conf); executeErr != nil {
-		return nil, fmt.Errorf("execute render: %s", executeErr)
+	parsedTmpl, parseErr := template.New(source).Option("missingkey=error").Parse(string(tmpl))
+
+	if parseErr != nil {
+		return nil, newTemplateError(source, parseErr)
 	}
 
-	rendered.Path = renderable.GetOutputPath()
+	var buf bytes.Buffer
+
+	if executeErr := parsedTmpl.Execute(&buf, data); executeErr != nil {
+		return nil, newTemplateError(source, executeErr)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// loadTemplate reads template at path from templates dir of conf, falling back to the embedded template if it was not
+// overridden. Templates are always read from AppFs. Source of the template is returned along with its content, so
+// errors point to the file which has to be fixed. Content is nil if template does not exist
+func loadTemplate(path string, conf *service.FullConfig) (source string, content []byte, err error) {
+	if conf.TemplatesDir != "" {
+		override := filepath.Join(conf.TemplatesDir, path)
+		content, readErr := afero.ReadFile(AppFs, override)
+
+		if readErr == nil {
+			return override, content, nil
+		}
+
+		if !os.IsNotExist(readErr) {
+			return override, nil, fmt.Errorf("read template: %s", readErr)
+		}
+	}
 
-	return rendered, nil
+	return path, box.Get(path), nil
 }
 
-func pathExists(path string) bool {
-	if _, statErr := AppFs.Stat(path); os.IsNotExist(statErr) {
+func pathExists(fs afero.Fs, path string) bool {
+	if _, statErr := fs.Stat(path); os.IsNotExist(statErr) {
 		return false
 	}
 
@@ -0,0 +1,146 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+
+	"github.com/Bocmah/phpdocker-gen/pkg/service"
+)
+
+type testRenderable struct {
+	templatePath string
+	outputPath   string
+}
+
+func (r *testRenderable) GetTemplatePath() string {
+	return r.templatePath
+}
+
+func (r *testRenderable) GetOutputPath() string {
+	return r.outputPath
+}
+
+func (r *testRenderable) GetTemplateData() interface{} {
+	return nil
+}
+
+func TestRender_TemplateErrors(t *testing.T) {
+	const templatesDir = "/home/test/templates"
+
+	var tests = []struct {
+		name         string
+		templatePath string
+		template     string
+		want         *TemplateError
+	}{
+		{
+			name:         "missing template",
+			templatePath: "/php/missing.gotmpl",
+			want:         &TemplateError{Path: "/php/missing.gotmpl", Message: "template not found"},
+		},
+		{
+			name:         "parse error",
+			templatePath: "/php/php.dockerfile.gotmpl",
+			template:     "FROM php:{{ .Services.PHP.Version }}\n\nRUN {{ if .AppName }}echo\n",
+			want: &TemplateError{
+				Path:    templatesDir + "/php/php.dockerfile.gotmpl",
+				Line:    4,
+				Message: "unexpected EOF",
+			},
+		},
+		{
+			name:         "unknown field",
+			templatePath: "/php/php.dockerfile.gotmpl",
+			template:     "FROM php:7.4\nWORKDIR {{ .ProjectRot }}\n",
+			want: &TemplateError{
+				Path:    templatesDir + "/php/php.dockerfile.gotmpl",
+				Line:    2,
+				Column:  11,
+				Message: `executing "/home/test/templates/php/php.dockerfile.gotmpl" at <.ProjectRot>: can't evaluate field ProjectRot in type *service.FullConfig`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			AppFs = afero.NewMemMapFs()
+			fs := afero.NewMemMapFs()
+
+			if tt.template != "" {
+				if err := afero.WriteFile(AppFs, templatesDir+tt.templatePath, []byte(tt.template), 0644); err != nil {
+					t.Fatalf("failed to write template: %s", err)
+				}
+			}
+
+			conf := &service.FullConfig{
+				AppName:      "awesome-app",
+				ProjectRoot:  "/home/test/app",
+				TemplatesDir: templatesDir,
+				Services:     &service.ServicesConfig{PHP: &service.PHPConfig{Version: "7.4"}},
+			}
+
+			renderable := &testRenderable{templatePath: tt.templatePath, outputPath: "/output/php/Dockerfile"}
+
+			err := render(fs, renderable, conf)
+
+			templateErr, ok := err.(*TemplateError)
+
+			if !ok {
+				t.Fatalf("render() returned %v, want *TemplateError", err)
+			}
+
+			if diff := cmp.Diff(tt.want, templateErr); diff != "" {
+				t.Errorf("render() error mismatch (-want +got):\n%s", diff)
+			}
+
+			if exists, _ := afero.Exists(fs, renderable.outputPath); exists {
+				t.Errorf("output file was written despite the error")
+			}
+		})
+	}
+}
+
+func TestRender_MissingMapKey(t *testing.T) {
+	AppFs = afero.NewMemMapFs()
+
+	const template = "ENV MYSQL_USER={{ .Services.Database.Environment.MYSQL_USR }}\n"
+
+	if err := afero.WriteFile(AppFs, "/templates/php/php.dockerfile.gotmpl", []byte(template), 0644); err != nil {
+		t.Fatalf("failed to write template: %s", err)
+	}
+
+	conf := &service.FullConfig{
+		AppName:      "awesome-app",
+		ProjectRoot:  "/home/test/app",
+		TemplatesDir: "/templates",
+		Services: &service.ServicesConfig{
+			PHP:      &service.PHPConfig{Version: "7.4"},
+			Database: &service.DatabaseConfig{System: service.MySQL, Credentials: service.Credentials{Username: "joe"}},
+		},
+	}
+
+	if _, err := execute("/php/php.dockerfile.gotmpl", conf, conf); err == nil {
+		t.Errorf("execute() returned nil error for missing map key")
+	}
+}
+
+func TestTemplateError_Error(t *testing.T) {
+	var tests = []struct {
+		err  *TemplateError
+		want string
+	}{
+		{&TemplateError{Path: "/a.gotmpl", Message: "template not found"}, "template /a.gotmpl: template not found"},
+		{&TemplateError{Path: "/a.gotmpl", Line: 3, Message: "unexpected EOF"}, "template /a.gotmpl:3: unexpected EOF"},
+		{&TemplateError{Path: "/a.gotmpl", Line: 3, Column: 7, Message: "bad"}, "template /a.gotmpl:3:7: bad"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error() got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
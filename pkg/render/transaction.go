@@ -0,0 +1,169 @@
+package render
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
+
+	"github.com/Bocmah/phpdocker-gen/pkg/service"
+)
+
+// Generate renders files for all services from conf, docker-compose file from compose and the manifest and writes
+// them to AppFs. Nothing is written if rendering fails. If writing of any file fails, previous contents of overwritten
+// files are restored and created files and directories are removed
+func Generate(conf *service.FullConfig, compose *dockercompose.Config) error {
+	files, renderErr := RenderToMemory(conf, compose)
+
+	if renderErr != nil {
+		return renderErr
+	}
+
+	manifest, manifestErr := manifestFile(conf, files)
+
+	if manifestErr != nil {
+		return manifestErr
+	}
+
+	tx := &transaction{}
+
+	for _, file := range append(files, manifest) {
+		if writeErr := tx.write(file); writeErr != nil {
+			if rollbackErr := tx.rollback(); rollbackErr != nil {
+				return fmt.Errorf("%s; rollback: %s", writeErr, rollbackErr)
+			}
+
+			return writeErr
+		}
+	}
+
+	return nil
+}
+
+// transaction writes files to AppFs and remembers how to undo the writes
+type transaction struct {
+	backups     []*backup
+	createdDirs []string
+}
+
+// backup is the state of the file before it was written by transaction
+type backup struct {
+	path    string
+	existed bool
+	content []byte
+	mode    os.FileMode
+}
+
+func (t *transaction) write(file *File) error {
+	if mkdirErr := t.createDir(filepath.Dir(file.Path)); mkdirErr != nil {
+		return mkdirErr
+	}
+
+	b := &backup{path: file.Path, mode: 0644}
+
+	if info, statErr := AppFs.Stat(file.Path); statErr == nil {
+		content, readErr := afero.ReadFile(AppFs, file.Path)
+
+		if readErr != nil {
+			return fmt.Errorf("backup %s: %s", file.Path, readErr)
+		}
+
+		b.existed, b.content, b.mode = true, content, info.Mode()
+	} else if !os.IsNotExist(statErr) {
+		return fmt.Errorf("backup %s: %s", file.Path, statErr)
+	}
+
+	t.backups = append(t.backups, b)
+
+	return writeAtomically(file.Path, file.Content, b.mode)
+}
+
+// createDir creates dir with all missing parents and remembers the topmost created one
+func (t *transaction) createDir(dir string) error {
+	topmost := ""
+
+	for d := dir; !pathExists(AppFs, d); d = filepath.Dir(d) {
+		topmost = d
+
+		if filepath.Dir(d) == d {
+			break
+		}
+	}
+
+	if topmost == "" {
+		return nil
+	}
+
+	if mkdirErr := AppFs.MkdirAll(dir, 0755); mkdirErr != nil {
+		return fmt.Errorf("MkdirAll: %s", mkdirErr)
+	}
+
+	t.createdDirs = append(t.createdDirs, topmost)
+
+	return nil
+}
+
+// rollback restores state of AppFs from before the transaction. It proceeds on errors and reports all of them
+func (t *transaction) rollback() error {
+	var errs []string
+
+	for i := len(t.backups) - 1; i >= 0; i-- {
+		b := t.backups[i]
+
+		if b.existed {
+			if err := writeAtomically(b.path, b.content, b.mode); err != nil {
+				errs = append(errs, fmt.Sprintf("restore %s: %s", b.path, err))
+			}
+		} else if err := AppFs.Remove(b.path); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, fmt.Sprintf("remove %s: %s", b.path, err))
+		}
+	}
+
+	for i := len(t.createdDirs) - 1; i >= 0; i-- {
+		if err := AppFs.RemoveAll(t.createdDirs[i]); err != nil {
+			errs = append(errs, fmt.Sprintf("remove %s: %s", t.createdDirs[i], err))
+		}
+	}
+
+	if len(errs) != 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// writeAtomically writes content to a temporary file next to path and renames it to path, so path never holds
+// partially written content
+func writeAtomically(path string, content []byte, mode os.FileMode) error {
+	tmp, createErr := afero.TempFile(AppFs, filepath.Dir(path), "."+filepath.Base(path)+".*")
+
+	if createErr != nil {
+		return fmt.Errorf("create temporary file: %s", createErr)
+	}
+
+	_, writeErr := tmp.Write(content)
+	closeErr := tmp.Close()
+
+	if writeErr == nil {
+		writeErr = closeErr
+	}
+
+	if writeErr == nil {
+		writeErr = AppFs.Chmod(tmp.Name(), mode)
+	}
+
+	if writeErr == nil {
+		writeErr = AppFs.Rename(tmp.Name(), path)
+	}
+
+	if writeErr != nil {
+		_ = AppFs.Remove(tmp.Name())
+		return fmt.Errorf("write %s: %s", path, writeErr)
+	}
+
+	return nil
+}
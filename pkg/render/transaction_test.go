@@ -0,0 +1,136 @@
+package render_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+
+	"github.com/Bocmah/phpdocker-gen/internal/dockercompose"
+	"github.com/Bocmah/phpdocker-gen/pkg/render"
+	"github.com/Bocmah/phpdocker-gen/pkg/service"
+)
+
+// failingFs fails to rename any file to failPath
+type failingFs struct {
+	afero.Fs
+	failPath string
+}
+
+func (fs *failingFs) Rename(oldname, newname string) error {
+	if newname == fs.failPath {
+		return errors.New("disk is full")
+	}
+
+	return fs.Fs.Rename(oldname, newname)
+}
+
+func createGenerateTestConf() *service.FullConfig {
+	return &service.FullConfig{
+		AppName:     "awesome-app",
+		ProjectRoot: "/home/test/app",
+		Services: &service.ServicesConfig{
+			PHP:   &service.PHPConfig{Version: "7.4"},
+			Nginx: &service.NginxConfig{ServerName: "awesomeapp", FastCGI: &service.FastCGI{PassPort: 9000}},
+		},
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	render.AppFs = afero.NewMemMapFs()
+
+	conf := createGenerateTestConf()
+	compose := &dockercompose.Config{Version: "3.8"}
+
+	if err := render.Generate(conf, compose); err != nil {
+		t.Fatalf("render.Generate() returned error: %s", err)
+	}
+
+	files, renderErr := render.RenderToMemory(conf, compose)
+
+	if renderErr != nil {
+		t.Fatalf("render.RenderToMemory() returned error: %s", renderErr)
+	}
+
+	for _, file := range files {
+		content, readErr := afero.ReadFile(render.AppFs, file.Path)
+
+		if readErr != nil {
+			t.Fatalf("file %s was not written: %s", file.Path, readErr)
+		}
+
+		if diff := cmp.Diff(string(file.Content), string(content)); diff != "" {
+			t.Errorf("%s mismatch (-want +got):\n%s", file.Path, diff)
+		}
+	}
+
+	modified, modifiedErr := render.ModifiedFiles(conf)
+
+	if modifiedErr != nil || len(modified) != 0 {
+		t.Errorf("render.ModifiedFiles() after generation got %v, %v, want nothing", modified, modifiedErr)
+	}
+
+	entries, readDirErr := afero.ReadDir(render.AppFs, "/home/test/app/.docker")
+
+	if readDirErr != nil {
+		t.Fatalf("failed to read output dir: %s", readDirErr)
+	}
+
+	var names []string
+
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	want := []string{render.ManifestFileName, "docker-compose.yml", "nginx", "php"}
+
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Errorf("temporary files were left in output dir (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerate_RollbackOnFailure(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	render.AppFs = &failingFs{Fs: fs, failPath: "/home/test/app/.docker/php/Dockerfile"}
+
+	conf := createGenerateTestConf()
+
+	createDir(t, fs, "/home/test/app/.docker")
+	createFile(t, fs, "/home/test/app/.docker/docker-compose.yml")
+
+	if err := render.Generate(conf, &dockercompose.Config{Version: "3.8"}); err == nil {
+		t.Fatalf("render.Generate() returned nil error, want rename error")
+	}
+
+	content, readErr := afero.ReadFile(fs, "/home/test/app/.docker/docker-compose.yml")
+
+	if readErr != nil {
+		t.Fatalf("overwritten file was removed: %s", readErr)
+	}
+
+	if string(content) != "test" {
+		t.Errorf("contents of overwritten file were not restored, got %q", content)
+	}
+
+	for _, path := range []string{
+		"/home/test/app/.docker/nginx",
+		"/home/test/app/.docker/php",
+		"/home/test/app/.docker/" + render.ManifestFileName,
+	} {
+		if _, err := fs.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("%s was not removed on rollback", path)
+		}
+	}
+
+	entries, readDirErr := afero.ReadDir(fs, "/home/test/app/.docker")
+
+	if readDirErr != nil {
+		t.Fatalf("failed to read output dir: %s", readDirErr)
+	}
+
+	if len(entries) != 1 {
+		t.Errorf("output dir got %d entries after rollback, want only docker-compose.yml", len(entries))
+	}
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/afero"
 
@@ -20,12 +21,23 @@ type Config interface {
 	Validate() error
 }
 
+// supportedConsistencies are consistency requirements of the project root bind mount
+var supportedConsistencies = []string{"consistent", "cached", "delegated"}
+
 // FullConfig is user-filled config from which resulted docker files will be generated
 type FullConfig struct {
-	AppName     string `yaml:"appName"`
-	ProjectRoot string `yaml:"projectRoot"`
-	OutputPath  string `yaml:"outputPath"`
-	Services    *ServicesConfig
+	AppName      string          `yaml:"appName"`
+	ProjectRoot  string          `yaml:"projectRoot"`
+	OutputPath   string          `yaml:"outputPath,omitempty"`
+	TemplatesDir string          `yaml:"templatesDir,omitempty"`
+	Services     *ServicesConfig `yaml:"services"`
+	// ProjectRootConsistency is a consistency of the project root bind mount (consistent, cached or delegated). It
+	// makes a difference only on Docker for Mac
+	ProjectRootConsistency string `yaml:"projectRootConsistency,omitempty"`
+	// Networks replace the default network of the app when they are declared
+	Networks []*NetworkConfig `yaml:"networks,omitempty"`
+	// Volumes are created in addition to the volumes which keep data of services
+	Volumes []*VolumeConfig `yaml:"volumes,omitempty"`
 }
 
 // FillDefaultsIfNotSet fills default parameters (if they are not present) for all services in the config
@@ -33,6 +45,14 @@ func (c *FullConfig) FillDefaultsIfNotSet() {
 	if c.Services != nil {
 		c.Services.FillDefaultsIfNotSet()
 	}
+
+	for _, network := range c.Networks {
+		network.FillDefaultsIfNotSet()
+	}
+
+	for _, volume := range c.Volumes {
+		volume.FillDefaultsIfNotSet()
+	}
 }
 
 // Validate validates all service parameters in the config
@@ -47,6 +67,10 @@ func (c *FullConfig) Validate() error {
 		errors.Add("Project root is required")
 	}
 
+	if c.ProjectRootConsistency != "" && !contains(supportedConsistencies, c.ProjectRootConsistency) {
+		errors.Add(fmt.Sprintf("Project root consistency %s is not supported", c.ProjectRootConsistency))
+	}
+
 	if c.Services == nil || c.Services.PresentServicesCount() == 0 {
 		errors.Add("At least one service is required")
 	}
@@ -61,6 +85,14 @@ func (c *FullConfig) Validate() error {
 				errors.Add(errs.Error())
 			}
 		}
+
+		if c.ProjectRoot != "" {
+			errors.Merge(c.validateInitScripts())
+		}
+
+		errors.Merge(c.validateServiceFiles())
+		errors.Merge(validateNetworks(c.Networks, c.Services))
+		errors.Merge(c.validateVolumes())
 	}
 
 	if errors.IsEmpty() {
@@ -70,6 +102,50 @@ func (c *FullConfig) Validate() error {
 	return errors
 }
 
+// validateInitScripts ensures that database init scripts exist inside the project root
+func (c *FullConfig) validateInitScripts() *ValidationErrors {
+	errors := &ValidationErrors{}
+	root := filepath.Clean(c.ProjectRoot)
+
+	for _, db := range c.Services.AllDatabases() {
+		for _, script := range db.InitScriptsOnHost(root) {
+			if !isInside(root, script) {
+				errors.Add(fmt.Sprintf("Database init script %s must be inside project root %s", script, root))
+				continue
+			}
+
+			info, statErr := AppFs.Stat(script)
+
+			if statErr != nil {
+				errors.Add(fmt.Sprintf("Database init script %s does not exist", script))
+				continue
+			}
+
+			if info.IsDir() {
+				errors.Add(fmt.Sprintf("Database init script %s is a directory", script))
+			}
+		}
+	}
+
+	return errors
+}
+
+// validateServiceFiles ensures that service files are rendered inside the output path
+func (c *FullConfig) validateServiceFiles() *ValidationErrors {
+	errors := &ValidationErrors{}
+	outputPath := filepath.Clean(c.GetOutputPath())
+
+	for _, files := range c.GetServiceFiles() {
+		for _, file := range files {
+			if !isInside(outputPath, filepath.Clean(file.PathOnHost)) {
+				errors.Add(fmt.Sprintf("Service file %s must be inside output path %s", file.PathOnHost, outputPath))
+			}
+		}
+	}
+
+	return errors
+}
+
 // GetServiceFiles returns paths to service files (Dockerfiles, configs, etc.) for each service in the config
 func (c *FullConfig) GetServiceFiles() Files {
 	outputPath := c.GetOutputPath()
@@ -81,18 +157,36 @@ func (c *FullConfig) GetServiceFiles() Files {
 		}
 	}
 
+	for _, db := range c.Services.AllDatabases() {
+		if file := db.ConfigFile(outputPath); file != nil {
+			files[Database] = append(files[Database], file)
+		}
+	}
+
 	return files
 }
 
-// GetEnvironment returns collection of environment variables for services which require them
+// GetEnvironment returns collection of environment variables for services which require them. Environment of
+// databases is returned by DatabaseConfig.Environment since a project may have several of them
 func (c *FullConfig) GetEnvironment() Environment {
-	if !c.Services.IsPresent(Database) {
-		return nil
+	env := Environment{}
+
+	if c.Services.IsPresent(MongoDB) {
+		if mongoEnv := c.Services.MongoDB.Environment(); len(mongoEnv) != 0 {
+			env[MongoDB] = mongoEnv
+		}
 	}
 
-	return Environment{
-		Database: c.Services.Database.Environment(),
+	if len(env) == 0 {
+		return nil
 	}
+
+	return env
+}
+
+// FormattedAppName returns app name in the form it is used in names of images, containers and volumes
+func (c *FullConfig) FormattedAppName() string {
+	return strings.ReplaceAll(strings.ToLower(c.AppName), " ", "-")
 }
 
 // GetOutputPath returns output path for resulting docker files
@@ -104,6 +198,11 @@ func (c *FullConfig) GetOutputPath() string {
 	return filepath.Join(c.ProjectRoot, ".docker")
 }
 
+// GetDockerComposePath returns path to which docker-compose.yml will be rendered
+func (c *FullConfig) GetDockerComposePath() string {
+	return filepath.Join(c.GetOutputPath(), "docker-compose.yml")
+}
+
 // LoadConfigFromFile reads file at filepath, validates data and transforms it into FullConfig
 func LoadConfigFromFile(filepath string) (*FullConfig, error) {
 	data, readFileErr := afero.ReadFile(AppFs, filepath)
@@ -327,6 +327,34 @@ func TestFullConfigValid_Validate(t *testing.T) {
 	}
 }
 
+func TestFullConfig_Validate_ImplicitDefaultNetwork(t *testing.T) {
+	conf := &service.FullConfig{
+		AppName:     "phpdocker-gen",
+		ProjectRoot: "/home/user/projects/test",
+		Services: &service.ServicesConfig{
+			PHP:    &service.PHPConfig{Version: "7.4"},
+			NodeJS: &service.NodeJSConfig{Version: "14"},
+			Database: &service.DatabaseConfig{
+				System:      service.MySQL,
+				Version:     "8.0",
+				Port:        3306,
+				Credentials: service.Credentials{RootPassword: "testRoot"},
+			},
+		},
+		Networks: []*service.NetworkConfig{
+			{
+				Name:     "proxy",
+				External: true,
+				Services: map[string]*service.NetworkMemberConfig{"nodejs": nil},
+			},
+		},
+	}
+
+	if validationErr := conf.Validate(); validationErr != nil {
+		t.Fatalf("Services which are not attached to declared networks do not share the default one: %s", validationErr)
+	}
+}
+
 func TestFullConfigInvalid_Validate(t *testing.T) {
 	tests := map[string]struct {
 		conf         *service.FullConfig
@@ -384,6 +412,153 @@ func TestFullConfigInvalid_Validate(t *testing.T) {
 			},
 			expectedErrs: []string{"At least one service is required"},
 		},
+		"unsupported project root consistency": {
+			conf: &service.FullConfig{
+				AppName:                "phpdocker-gen",
+				ProjectRoot:            "/home/user/projects/test",
+				ProjectRootConsistency: "fast",
+				Services: &service.ServicesConfig{
+					PHP: &service.PHPConfig{Version: "7.4"},
+				},
+			},
+			expectedErrs: []string{"Project root consistency fast is not supported"},
+		},
+		"invalid networks": {
+			conf: &service.FullConfig{
+				AppName:     "phpdocker-gen",
+				ProjectRoot: "/home/user/projects/test",
+				Services: &service.ServicesConfig{
+					PHP: &service.PHPConfig{Version: "7.4"},
+				},
+				Networks: []*service.NetworkConfig{
+					{Name: "backend", Driver: "bridge"},
+					{Name: "backend", Driver: "bridge"},
+					{
+						Name:     "proxy",
+						External: true,
+						Services: map[string]*service.NetworkMemberConfig{"webserver": nil},
+					},
+				},
+			},
+			expectedErrs: []string{
+				"Network backend is declared more than once",
+				"Network proxy has unknown service webserver",
+			},
+		},
+		"networks separating connected services": {
+			conf: &service.FullConfig{
+				AppName:     "phpdocker-gen",
+				ProjectRoot: "/home/user/projects/test",
+				Services: &service.ServicesConfig{
+					PHP:      &service.PHPConfig{Version: "7.4"},
+					Nginx:    &service.NginxConfig{ServerName: "test-server"},
+					Database: &service.DatabaseConfig{System: service.MySQL, Version: "8.0"},
+					Redis:    &service.RedisConfig{Version: "6.0"},
+				},
+				Networks: []*service.NetworkConfig{
+					{
+						Name:     "proxy",
+						External: true,
+						Services: map[string]*service.NetworkMemberConfig{"webserver": nil},
+					},
+					{
+						Name:     "backend",
+						Services: map[string]*service.NetworkMemberConfig{"php-fpm": nil, "db": nil},
+					},
+				},
+			},
+			expectedErrs: []string{
+				"Services php-fpm and redis do not share a network",
+				"Services webserver and php-fpm do not share a network",
+			},
+		},
+		"database service name escaping output path": {
+			conf: &service.FullConfig{
+				AppName:     "phpdocker-gen",
+				ProjectRoot: "/home/user/projects/test",
+				Services: &service.ServicesConfig{
+					Database: &service.DatabaseConfig{
+						Service:     "../../escaped",
+						System:      service.MySQL,
+						Version:     "8.0",
+						Port:        3306,
+						Credentials: service.Credentials{RootPassword: "testRoot"},
+						Settings:    map[string]string{"max_connections": "200"},
+					},
+				},
+			},
+			expectedErrs: []string{
+				`Database service name "../../escaped" is invalid`,
+				"Service file /home/user/projects/escaped/my.cnf must be inside output path /home/user/projects/test/.docker",
+			},
+		},
+		"invalid volumes": {
+			conf: &service.FullConfig{
+				AppName:     "phpdocker-gen",
+				ProjectRoot: "/home/user/projects/test",
+				Services: &service.ServicesConfig{
+					PHP: &service.PHPConfig{Version: "7.4"},
+				},
+				Volumes: []*service.VolumeConfig{
+					{Name: "composer-cache", Driver: "local"},
+					{Name: "composer-cache", Driver: "local"},
+					{
+						Name:     "node-modules",
+						Driver:   "local",
+						Services: map[string]*service.VolumeMountConfig{"nodejs": {Target: "/var/www/node_modules"}},
+					},
+				},
+			},
+			expectedErrs: []string{
+				"Volume composer-cache is declared more than once",
+				"Volume node-modules has unknown service nodejs",
+			},
+		},
+		"volumes clashing with generated volumes and mounts": {
+			conf: &service.FullConfig{
+				AppName:     "Test App",
+				ProjectRoot: "/home/user/projects/test",
+				Services: &service.ServicesConfig{
+					PHP: &service.PHPConfig{Version: "7.4"},
+					Database: &service.DatabaseConfig{
+						System:      service.MySQL,
+						Version:     "8.0",
+						Port:        3306,
+						Credentials: service.Credentials{RootPassword: "testRoot"},
+					},
+					Redis: &service.RedisConfig{Version: "6", Port: 6379, Persistence: true},
+				},
+				Volumes: []*service.VolumeConfig{
+					{
+						Name:     "test-app-data",
+						Driver:   "local",
+						Services: map[string]*service.VolumeMountConfig{"db": {Target: "/var/lib/mysql/"}},
+					},
+					{
+						Name:     "test-app-redis-data",
+						Driver:   "local",
+						Services: map[string]*service.VolumeMountConfig{"php-fpm": {Target: "/var/www"}},
+					},
+					{
+						Name:     "cache",
+						Driver:   "local",
+						Services: map[string]*service.VolumeMountConfig{"php-fpm": {Target: "/var/cache"}},
+					},
+					{
+						Name:     "shared-cache",
+						Driver:   "local",
+						Services: map[string]*service.VolumeMountConfig{"php-fpm": {Target: "/var/cache"}},
+					},
+				},
+			},
+			expectedErrs: []string{
+				"Volume name test-app-data is reserved for data of db",
+				"Volume name test-app-redis-data is reserved for data of redis",
+				"Volume test-app-data target /var/lib/mysql/ of db is already used by volume test-app-data",
+				"Volume test-app-redis-data target /var/www of php-fpm is already used by project root",
+				"Volume shared-cache target /var/cache of php-fpm is already used by volume cache",
+			},
+		},
 	}
 
 	for name, tc := range tests {
@@ -405,6 +580,70 @@ func TestFullConfigInvalid_Validate(t *testing.T) {
 	}
 }
 
+func TestFullConfig_Validate_InitScripts(t *testing.T) {
+	service.AppFs = afero.NewMemMapFs()
+
+	for _, file := range []string{"/home/user/app/db/schema.sql", "/home/user/seed.sql"} {
+		if err := afero.WriteFile(service.AppFs, file, []byte("SELECT 1;"), 0644); err != nil {
+			t.Fatalf("failed to write %s: %s", file, err)
+		}
+	}
+
+	if err := service.AppFs.MkdirAll("/home/user/app/db/dir.sql", 0755); err != nil {
+		t.Fatalf("failed to create directory: %s", err)
+	}
+
+	tests := map[string]struct {
+		scripts  []string
+		wantErrs []string
+	}{
+		"missing file": {
+			scripts:  []string{"db/missing.sql"},
+			wantErrs: []string{"Database init script /home/user/app/db/missing.sql does not exist"},
+		},
+		"outside of project root": {
+			scripts: []string{"../seed.sql", "/home/user/seed.sql"},
+			wantErrs: []string{
+				"Database init script /home/user/seed.sql must be inside project root /home/user/app",
+			},
+		},
+		"directory": {
+			scripts:  []string{"db/dir.sql"},
+			wantErrs: []string{"Database init script /home/user/app/db/dir.sql is a directory"},
+		},
+	}
+
+	conf := func(scripts []string) *service.FullConfig {
+		return &service.FullConfig{
+			AppName:     "app",
+			ProjectRoot: "/home/user/app",
+			Services: &service.ServicesConfig{
+				Database: &service.DatabaseConfig{
+					System:      service.PostgreSQL,
+					Version:     "12.3",
+					Port:        5432,
+					Credentials: service.Credentials{Password: "secret"},
+					InitScripts: scripts,
+				},
+			},
+		}
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs := conf(tc.scripts).Validate()
+
+			if errs == nil {
+				t.Fatalf("Did not return any errors for init scripts %v", tc.scripts)
+			}
+
+			failTestOnUnspottedError(validationResult{wantErrs: tc.wantErrs, actualErrs: errs, validatedVal: tc.scripts}, t)
+		})
+	}
+
+	failTestOnErrorsOnCorrectInput(conf([]string{"db/schema.sql"}).Validate(), t)
+}
+
 func TestFullConfig_GetServiceFiles(t *testing.T) {
 	outputPath := "/home/user/output"
 
@@ -520,12 +759,23 @@ func TestFullConfig_GetEnvironment(t *testing.T) {
 
 	env = conf.GetEnvironment()
 
+	if env != nil {
+		t.Errorf("encountered environment of the database which is provided by DatabaseConfig: %s", env)
+	}
+
+	conf.Services.MongoDB = &service.MongoDBConfig{
+		Version:  "4.4",
+		Port:     27017,
+		Username: "root",
+		Password: "secret",
+	}
+
+	env = conf.GetEnvironment()
+
 	wantEnv := service.Environment{
-		service.Database: {
-			"MYSQL_ROOT_PASSWORD": "testRoot",
-			"MYSQL_DATABASE":      "test-db",
-			"MYSQL_USER":          "bocmah",
-			"MYSQL_PASSWORD":      "test",
+		service.MongoDB: {
+			"MONGO_INITDB_ROOT_USERNAME": "root",
+			"MONGO_INITDB_ROOT_PASSWORD": "secret",
 		},
 	}
 
@@ -574,3 +824,24 @@ func TestFullConfig_GetOutputPath(t *testing.T) {
 		t.Errorf("incorrect output path for config with explicitly set OutputPath. got %s, want %s", got, want)
 	}
 }
+
+func TestFullConfig_GetDockerComposePath(t *testing.T) {
+	conf := &service.FullConfig{
+		AppName:     "phpdocker-gen",
+		ProjectRoot: "/home/user/projects/test",
+	}
+
+	want := "/home/user/projects/test/.docker/docker-compose.yml"
+
+	if got := conf.GetDockerComposePath(); got != want {
+		t.Errorf("incorrect docker-compose path. got %s, want %s", got, want)
+	}
+
+	conf.OutputPath = "/home/test/output"
+
+	want = "/home/test/output/docker-compose.yml"
+
+	if got := conf.GetDockerComposePath(); got != want {
+		t.Errorf("incorrect docker-compose path for config with explicitly set OutputPath. got %s, want %s", got, want)
+	}
+}
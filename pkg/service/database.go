@@ -1,6 +1,14 @@
 package service
 
-import "fmt"
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
 
 // SupportedSystem is one of database systems supported by the tool (e.g. MySQL)
 type SupportedSystem string
@@ -17,45 +25,156 @@ func (s SupportedSystem) DataPath() string {
 	return ""
 }
 
+// ConfigPath returns path inside the container to the server configuration file which is rendered from settings
+func (s SupportedSystem) ConfigPath() string {
+	defs, ok := defaults[s]
+
+	if ok {
+		return defs.config.pathInContainer
+	}
+
+	return ""
+}
+
+// Image returns name of the official Docker image for the database system
+func (s SupportedSystem) Image() string {
+	defs, ok := defaults[s]
+
+	if ok {
+		return defs.image
+	}
+
+	return ""
+}
+
+// DefaultPort returns the port the database server listens on unless configured otherwise
+func (s SupportedSystem) DefaultPort() int {
+	defs, ok := defaults[s]
+
+	if ok {
+		return defs.port
+	}
+
+	return 0
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. Aliases (e.g. postgres) are resolved to the canonical system name
+func (s *SupportedSystem) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var name string
+
+	if err := unmarshal(&name); err != nil {
+		return err
+	}
+
+	name = strings.ToLower(name)
+
+	if system, ok := systemAliases[name]; ok {
+		*s = system
+	} else {
+		*s = SupportedSystem(name)
+	}
+
+	return nil
+}
+
 // All supported systems
 const (
 	MySQL      SupportedSystem = "mysql"
-	PostgreSQL SupportedSystem = "posgresql"
+	PostgreSQL SupportedSystem = "postgresql"
+	MariaDB    SupportedSystem = "mariadb"
 )
 
+var systemAliases = map[string]SupportedSystem{
+	"postgres": PostgreSQL,
+	// posgresql is the misspelled identifier which was used for PostgreSQL before, configs written for it keep working
+	"posgresql": PostgreSQL,
+}
+
 type systemDefaults struct {
+	image    string
 	version  string
 	port     int
 	dataPath string
+	// config is the server configuration file which is rendered from settings. Settings are passed to the server as
+	// arguments if it is not set
+	config serverConfig
+}
+
+type serverConfig struct {
+	fileName        string
+	pathInContainer string
+	templatePath    string
+}
+
+var myCnf = serverConfig{
+	fileName:        "my.cnf",
+	pathInContainer: "/etc/mysql/conf.d/app.cnf",
+	templatePath:    "/mysql/my.cnf.gotmpl",
 }
 
 var defaults = map[SupportedSystem]systemDefaults{
 	MySQL: {
+		image:    "mysql",
 		version:  "8.0",
 		port:     3306,
 		dataPath: "/var/lib/mysql",
+		config:   myCnf,
 	},
 	PostgreSQL: {
+		image:    "postgres",
 		version:  "12.3",
 		port:     5432,
 		dataPath: "/var/lib/postgresql/data",
 	},
+	MariaDB: {
+		image:    "mariadb",
+		version:  "10.5",
+		port:     3306,
+		dataPath: "/var/lib/mysql",
+		config:   myCnf,
+	},
 }
 
+var settingNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// serviceNameRegexp matches names which are valid both as docker-compose service names and as directory names inside
+// the output path. Path separators and names like .. do not match
+var serviceNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// InitScriptsPath is the directory inside the container from which database images run initialization scripts on the
+// first start
+const InitScriptsPath = "/docker-entrypoint-initdb.d"
+
+var initScriptExtensions = []string{".sql", ".sql.gz", ".sh"}
+
+// DefaultDatabaseService is the name of database service which is used unless another name is specified
+const DefaultDatabaseService = "db"
+
 // Credentials is database credentials
 type Credentials struct {
-	Username     string
-	Password     string
-	RootPassword string `yaml:"rootPassword"`
+	Username     string `yaml:",omitempty"`
+	Password     string `yaml:",omitempty"`
+	RootPassword string `yaml:"rootPassword,omitempty"`
 }
 
 // DatabaseConfig is a config for database service
 type DatabaseConfig struct {
-	System      SupportedSystem
-	Version     string
-	Name        string
-	Port        int
-	Credentials `yaml:",inline"`
+	// Service is the name of docker-compose service and container of the database
+	Service string `yaml:",omitempty"`
+	System  SupportedSystem
+	Version string `yaml:",omitempty"`
+	Name    string `yaml:",omitempty"`
+	// Port is the port on the host. The server listens on ContainerPort inside the container
+	Port int `yaml:",omitempty"`
+	// ContainerPort is the port the server listens on inside the container. Default port of the system is used when
+	// it is not set
+	ContainerPort int `yaml:"containerPort,omitempty"`
+	PortBinding   `yaml:",inline"`
+	Credentials   `yaml:",inline"`
+	// InitScripts are paths to .sql, .sql.gz and .sh files relative to the project root
+	InitScripts []string `yaml:"initScripts,omitempty"`
+	// Settings are written to my.cnf or passed to postgres as -c arguments
+	Settings map[string]string `yaml:",omitempty"`
 }
 
 // FillDefaultsIfNotSet fills default database parameters if they are not present
@@ -73,18 +192,46 @@ func (d *DatabaseConfig) FillDefaultsIfNotSet() {
 	}
 }
 
+// TargetPort returns the port the server listens on inside the container
+func (d *DatabaseConfig) TargetPort() int {
+	if d.ContainerPort != 0 {
+		return d.ContainerPort
+	}
+
+	return d.System.DefaultPort()
+}
+
+// ServiceName returns the name of docker-compose service and container of the database
+func (d *DatabaseConfig) ServiceName() string {
+	if d.Service != "" {
+		return d.Service
+	}
+
+	return DefaultDatabaseService
+}
+
 // Validate validates database parameters
 func (d *DatabaseConfig) Validate() error {
 	errors := &ValidationErrors{}
 
-	if d.System != MySQL && d.System != PostgreSQL {
+	if _, ok := defaults[d.System]; !ok {
 		errors.Add("Unsupported database system")
 	}
 
+	if d.Service != "" && !serviceNameRegexp.MatchString(d.Service) {
+		errors.Add(fmt.Sprintf("Database service name %q is invalid", d.Service))
+	}
+
 	if d.Port == 0 {
 		errors.Add("DatabaseConfig port is required")
 	}
 
+	d.PortBinding.validate("DatabaseConfig", errors)
+
+	if _, ok := d.Settings["port"]; ok {
+		errors.Add("Database setting port is not allowed, containerPort is used instead")
+	}
+
 	if d.System == MySQL && d.RootPassword == "" {
 		errors.Add("DatabaseConfig root password is required for MySQL")
 	}
@@ -93,6 +240,44 @@ func (d *DatabaseConfig) Validate() error {
 		errors.Add("DatabaseConfig password is required for PostgreSQL")
 	}
 
+	if d.System == MariaDB && d.RootPassword == "" {
+		errors.Add("DatabaseConfig root password is required for MariaDB")
+	}
+
+	if d.System == MariaDB && d.Password != "" && d.Username == "" {
+		errors.Add("DatabaseConfig username is required for MariaDB when password is set")
+	}
+
+	scripts := map[string]string{}
+
+	for _, script := range d.InitScripts {
+		if !hasInitScriptExtension(script) {
+			errors.Add(fmt.Sprintf(
+				"Database init script %s must have one of extensions %s",
+				script,
+				strings.Join(initScriptExtensions, ", "),
+			))
+		}
+
+		name := filepath.Base(script)
+
+		if other, ok := scripts[name]; ok {
+			errors.Add(fmt.Sprintf("Database init scripts %s and %s have the same file name", other, script))
+		}
+
+		scripts[name] = script
+	}
+
+	for _, name := range d.settingNames() {
+		if !settingNameRegexp.MatchString(name) {
+			errors.Add(fmt.Sprintf("Database setting name %q is invalid", name))
+		}
+
+		if strings.ContainsAny(d.Settings[name], "\r\n") {
+			errors.Add(fmt.Sprintf("Database setting %s must not contain line breaks", name))
+		}
+	}
+
 	if errors.IsEmpty() {
 		return nil
 	}
@@ -100,16 +285,138 @@ func (d *DatabaseConfig) Validate() error {
 	return errors
 }
 
+// InitScriptsOnHost returns absolute paths to init scripts. Relative paths are resolved against projectRoot
+func (d *DatabaseConfig) InitScriptsOnHost(projectRoot string) []string {
+	var paths []string
+
+	for _, script := range d.InitScripts {
+		if filepath.IsAbs(script) {
+			paths = append(paths, filepath.Clean(script))
+		} else {
+			paths = append(paths, filepath.Join(projectRoot, script))
+		}
+	}
+
+	return paths
+}
+
+// InitScriptsInContainer returns paths inside the container to which init scripts are mounted in the same order as
+// InitScriptsOnHost
+func (d *DatabaseConfig) InitScriptsInContainer() []string {
+	var paths []string
+
+	for _, script := range d.InitScripts {
+		paths = append(paths, path.Join(InitScriptsPath, filepath.Base(script)))
+	}
+
+	return paths
+}
+
+// ConfigFile returns server configuration file which is rendered from settings into the directory named after the
+// database service. Nil is returned if there are no settings or the system does not read them from the file
+func (d *DatabaseConfig) ConfigFile(outputPath string) *File {
+	config := defaults[d.System].config
+
+	if len(d.ServerSettings()) == 0 || config.templatePath == "" {
+		return nil
+	}
+
+	return &File{
+		Type:            ConfigFile,
+		PathOnHost:      filepath.Join(outputPath, d.ServiceName(), config.fileName),
+		PathInContainer: config.pathInContainer,
+		TemplatePath:    config.templatePath,
+		TemplateData:    d,
+	}
+}
+
+// ServerArgs returns arguments for the database server which apply settings of PostgreSQL, each of them as
+// -c name=value. Nil is returned for other systems since they read the configuration file
+func (d *DatabaseConfig) ServerArgs() []string {
+	if d.System != PostgreSQL {
+		return nil
+	}
+
+	settings := d.ServerSettings()
+	names := make([]string, 0, len(settings))
+
+	for name := range settings {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var args []string
+
+	for _, name := range names {
+		args = append(args, "-c", name+"="+settings[name])
+	}
+
+	return args
+}
+
+// ServerSettings returns settings with which the server is configured. The image does not know about containerPort, so
+// the server is told to listen on it with port setting. Nil is returned if there are none
+func (d *DatabaseConfig) ServerSettings() map[string]string {
+	settings := map[string]string{}
+
+	for name, value := range d.Settings {
+		settings[name] = value
+	}
+
+	if port := d.ContainerPort; port != 0 && port != d.System.DefaultPort() {
+		settings["port"] = strconv.Itoa(port)
+	}
+
+	if len(settings) == 0 {
+		return nil
+	}
+
+	return settings
+}
+
+func (d *DatabaseConfig) settingNames() []string {
+	names := make([]string, 0, len(d.Settings))
+
+	for name := range d.Settings {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// IsEmpty determines whether config is empty
+func (d *DatabaseConfig) IsEmpty() bool {
+	return d.Service == "" &&
+		d.System == "" &&
+		d.Version == "" &&
+		d.Name == "" &&
+		d.Port == 0 &&
+		d.ContainerPort == 0 &&
+		d.PortBinding == (PortBinding{}) &&
+		d.Credentials == (Credentials{}) &&
+		len(d.InitScripts) == 0 &&
+		len(d.Settings) == 0
+}
+
 func (d *DatabaseConfig) String() string {
 	return fmt.Sprintf(
-		"DatabaseConfig{System: %v, Version: %s, Name: %s, HTTPPort: %d, Username: %s, Password: %s, RootPassword: %s}",
+		"DatabaseConfig{Service: %s, System: %v, Version: %s, Name: %s, HTTPPort: %d, ContainerPort: %d, HostIP: %s, ExposeOnly: %t, Username: %s, Password: %s, RootPassword: %s, InitScripts: %v, Settings: %v}",
+		d.Service,
 		d.System,
 		d.Version,
 		d.Name,
 		d.Port,
+		d.ContainerPort,
+		d.HostIP,
+		d.ExposeOnly,
 		d.Username,
 		d.Password,
 		d.RootPassword,
+		d.InitScripts,
+		d.Settings,
 	)
 }
 
@@ -120,6 +427,8 @@ func (d *DatabaseConfig) Environment() map[string]string {
 		return d.mySQLEnvironment()
 	case PostgreSQL:
 		return d.postgreSQLEnvironment()
+	case MariaDB:
+		return d.mariaDBEnvironment()
 	default:
 		return map[string]string{}
 	}
@@ -164,3 +473,35 @@ func (d *DatabaseConfig) postgreSQLEnvironment() map[string]string {
 
 	return env
 }
+
+func (d *DatabaseConfig) mariaDBEnvironment() map[string]string {
+	env := map[string]string{}
+
+	if d.RootPassword != "" {
+		env["MARIADB_ROOT_PASSWORD"] = d.RootPassword
+	}
+
+	if d.Name != "" {
+		env["MARIADB_DATABASE"] = d.Name
+	}
+
+	if d.Username != "" {
+		env["MARIADB_USER"] = d.Username
+	}
+
+	if d.Password != "" {
+		env["MARIADB_PASSWORD"] = d.Password
+	}
+
+	return env
+}
+
+func hasInitScriptExtension(script string) bool {
+	for _, ext := range initScriptExtensions {
+		if strings.HasSuffix(script, ext) {
+			return true
+		}
+	}
+
+	return false
+}
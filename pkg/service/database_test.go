@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v2"
 
 	"github.com/Bocmah/phpdocker-gen/pkg/service"
 )
@@ -47,6 +48,10 @@ func TestSupportedSystem_DataPath(t *testing.T) {
 			input: service.PostgreSQL,
 			want:  "/var/lib/postgresql/data",
 		},
+		"mariadb": {
+			input: service.MariaDB,
+			want:  "/var/lib/mysql",
+		},
 		"unknown": {
 			input: service.SupportedSystem("unknown"),
 			want:  "",
@@ -64,6 +69,124 @@ func TestSupportedSystem_DataPath(t *testing.T) {
 	}
 }
 
+func TestSupportedSystem_Image(t *testing.T) {
+	tests := map[string]struct {
+		input service.SupportedSystem
+		want  string
+	}{
+		"mysql": {
+			input: service.MySQL,
+			want:  "mysql",
+		},
+		"postgresql": {
+			input: service.PostgreSQL,
+			want:  "postgres",
+		},
+		"mariadb": {
+			input: service.MariaDB,
+			want:  "mariadb",
+		},
+		"unknown": {
+			input: service.SupportedSystem("unknown"),
+			want:  "",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := tc.input.Image()
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("SupportedSystem.Image() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSupportedSystem_DefaultPort(t *testing.T) {
+	tests := map[string]struct {
+		input service.SupportedSystem
+		want  int
+	}{
+		"mysql": {
+			input: service.MySQL,
+			want:  3306,
+		},
+		"postgresql": {
+			input: service.PostgreSQL,
+			want:  5432,
+		},
+		"mariadb": {
+			input: service.MariaDB,
+			want:  3306,
+		},
+		"unknown": {
+			input: service.SupportedSystem("unknown"),
+			want:  0,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := tc.input.DefaultPort()
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("SupportedSystem.DefaultPort() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSupportedSystem_UnmarshalYAML(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  service.SupportedSystem
+	}{
+		"mysql": {
+			input: "system: mysql",
+			want:  service.MySQL,
+		},
+		"postgresql": {
+			input: "system: postgresql",
+			want:  service.PostgreSQL,
+		},
+		"postgres alias": {
+			input: "system: postgres",
+			want:  service.PostgreSQL,
+		},
+		"legacy posgresql identifier": {
+			input: "system: posgresql",
+			want:  service.PostgreSQL,
+		},
+		"mixed case": {
+			input: "system: PostgreSQL",
+			want:  service.PostgreSQL,
+		},
+		"mariadb": {
+			input: "system: mariadb",
+			want:  service.MariaDB,
+		},
+		"unknown is kept for validation": {
+			input: "system: oracle",
+			want:  service.SupportedSystem("oracle"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := service.DatabaseConfig{}
+
+			if err := yaml.Unmarshal([]byte(tc.input), &got); err != nil {
+				t.Fatalf("yaml.Unmarshal() returned error: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got.System); diff != "" {
+				t.Fatalf("SupportedSystem.UnmarshalYAML() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestDatabaseConfig_FillDefaultsIfNotSet(t *testing.T) {
 	db := service.DatabaseConfig{}
 
@@ -75,8 +198,24 @@ func TestDatabaseConfig_FillDefaultsIfNotSet(t *testing.T) {
 		Version: "8.0",
 	}
 
-	if db != want {
-		t.Errorf("Incorrect defaults, want %v, got %v", want, db)
+	if diff := cmp.Diff(want, db); diff != "" {
+		t.Errorf("Incorrect defaults (-want +got):\n%s", diff)
+	}
+}
+
+func TestDatabaseConfig_FillDefaultsIfNotSet_MariaDB(t *testing.T) {
+	db := service.DatabaseConfig{System: service.MariaDB}
+
+	db.FillDefaultsIfNotSet()
+
+	want := service.DatabaseConfig{
+		System:  service.MariaDB,
+		Port:    3306,
+		Version: "10.5",
+	}
+
+	if diff := cmp.Diff(want, db); diff != "" {
+		t.Errorf("Incorrect defaults (-want +got):\n%s", diff)
 	}
 }
 
@@ -124,6 +263,79 @@ func TestDatabaseConfig_ValidateIncorrectInput(t *testing.T) {
 				"DatabaseConfig password is required for PostgreSQL",
 			},
 		},
+		"MariaDB without root password": {
+			conf: &service.DatabaseConfig{
+				System:  service.MariaDB,
+				Version: "10.5",
+				Port:    3306,
+			},
+			wantErrs: []string{
+				"DatabaseConfig root password is required for MariaDB",
+			},
+		},
+		"MariaDB with password but without username": {
+			conf: &service.DatabaseConfig{
+				System:  service.MariaDB,
+				Version: "10.5",
+				Port:    3306,
+				Credentials: service.Credentials{
+					Password:     "test-password",
+					RootPassword: "test-root-password",
+				},
+			},
+			wantErrs: []string{
+				"DatabaseConfig username is required for MariaDB when password is set",
+			},
+		},
+		"with invalid host IP": {
+			conf: &service.DatabaseConfig{
+				System:      service.MySQL,
+				Version:     "8.0",
+				Port:        3306,
+				PortBinding: service.PortBinding{HostIP: "localhost"},
+				Credentials: service.Credentials{RootPassword: "test-root-password"},
+			},
+			wantErrs: []string{
+				"DatabaseConfig host IP localhost is invalid",
+			},
+		},
+		"with host IP and expose-only port": {
+			conf: &service.DatabaseConfig{
+				System:      service.MySQL,
+				Version:     "8.0",
+				Port:        3306,
+				PortBinding: service.PortBinding{HostIP: "127.0.0.1", ExposeOnly: true},
+				Credentials: service.Credentials{RootPassword: "test-root-password"},
+			},
+			wantErrs: []string{
+				"DatabaseConfig host IP can not be set when port is expose-only",
+			},
+		},
+		"with invalid service name": {
+			conf: &service.DatabaseConfig{
+				Service:     "../db",
+				System:      service.MySQL,
+				Version:     "8.0",
+				Port:        3306,
+				Credentials: service.Credentials{RootPassword: "test-root-password"},
+			},
+			wantErrs: []string{
+				`Database service name "../db" is invalid`,
+			},
+		},
+		"with port setting": {
+			conf: &service.DatabaseConfig{
+				System:        service.PostgreSQL,
+				Version:       "12",
+				Port:          5432,
+				ContainerPort: 5433,
+				Credentials:   service.Credentials{Password: "test-password"},
+				Settings:      map[string]string{"port": "5433"},
+			},
+			wantErrs: []string{
+				"Database setting port is not allowed, containerPort is used instead",
+			},
+		},
 	}
 
 	for name, tc := range tests {
@@ -145,6 +357,160 @@ func TestDatabaseConfig_ValidateIncorrectInput(t *testing.T) {
 	}
 }
 
+func TestDatabaseConfig_ValidateInitScripts(t *testing.T) {
+	db := service.DatabaseConfig{
+		System:      service.PostgreSQL,
+		Port:        5432,
+		Credentials: service.Credentials{Password: "secret"},
+		InitScripts: []string{"db/schema.sql", "db/seed.sql.gz", "db/users.sh", "db/notes.txt", "legacy/schema.sql"},
+	}
+
+	errs := db.Validate()
+
+	if errs == nil {
+		t.Fatalf("Did not return any errors for value %v", db)
+	}
+
+	res := validationResult{
+		wantErrs: []string{
+			"Database init script db/notes.txt must have one of extensions .sql, .sql.gz, .sh",
+			"Database init scripts db/schema.sql and legacy/schema.sql have the same file name",
+		},
+		actualErrs:   errs,
+		validatedVal: db,
+	}
+
+	failTestOnUnspottedError(res, t)
+
+	if got := len(*errs.(*service.ValidationErrors)); got != 2 {
+		t.Errorf("Expected 2 errors, got %d: %s", got, errs)
+	}
+}
+
+func TestDatabaseConfig_InitScriptsOnHost(t *testing.T) {
+	db := service.DatabaseConfig{InitScripts: []string{"db/schema.sql", "/srv/app/seed.sql", "./db/../users.sh"}}
+
+	want := []string{"/srv/app/db/schema.sql", "/srv/app/seed.sql", "/srv/app/users.sh"}
+
+	if diff := cmp.Diff(want, db.InitScriptsOnHost("/srv/app")); diff != "" {
+		t.Errorf("InitScriptsOnHost() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDatabaseConfig_ValidateSettings(t *testing.T) {
+	db := service.DatabaseConfig{
+		System:      service.PostgreSQL,
+		Port:        5432,
+		Credentials: service.Credentials{Password: "secret"},
+		Settings: map[string]string{
+			"max_connections": "200",
+			"bad name":        "1",
+			"work_mem":        "4MB\nfsync = off",
+		},
+	}
+
+	errs := db.Validate()
+
+	if errs == nil {
+		t.Fatalf("Did not return any errors for value %v", db)
+	}
+
+	res := validationResult{
+		wantErrs: []string{
+			`Database setting name "bad name" is invalid`,
+			"Database setting work_mem must not contain line breaks",
+		},
+		actualErrs:   errs,
+		validatedVal: db,
+	}
+
+	failTestOnUnspottedError(res, t)
+}
+
+func TestDatabaseConfig_UnmarshalSettings(t *testing.T) {
+	input := "system: mysql\nsettings:\n  max_connections: 200\n  sql_mode: STRICT_TRANS_TABLES\n"
+
+	got := service.DatabaseConfig{}
+
+	if err := yaml.Unmarshal([]byte(input), &got); err != nil {
+		t.Fatalf("yaml.Unmarshal() returned error: %s", err)
+	}
+
+	want := map[string]string{"max_connections": "200", "sql_mode": "STRICT_TRANS_TABLES"}
+
+	if diff := cmp.Diff(want, got.Settings); diff != "" {
+		t.Errorf("settings mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDatabaseConfig_ConfigFile(t *testing.T) {
+	settings := map[string]string{"max_connections": "200"}
+
+	tests := map[string]struct {
+		conf     *service.DatabaseConfig
+		want     *service.File
+		wantArgs []string
+	}{
+		"without settings": {
+			conf: &service.DatabaseConfig{System: service.MySQL},
+			want: nil,
+		},
+		"MySQL": {
+			conf: &service.DatabaseConfig{System: service.MySQL, Settings: settings},
+			want: &service.File{
+				Type:            service.ConfigFile,
+				PathOnHost:      "/out/db/my.cnf",
+				PathInContainer: "/etc/mysql/conf.d/app.cnf",
+				TemplatePath:    "/mysql/my.cnf.gotmpl",
+			},
+		},
+		"MySQL with container port": {
+			conf: &service.DatabaseConfig{System: service.MySQL, ContainerPort: 3307},
+			want: &service.File{
+				Type:            service.ConfigFile,
+				PathOnHost:      "/out/db/my.cnf",
+				PathInContainer: "/etc/mysql/conf.d/app.cnf",
+				TemplatePath:    "/mysql/my.cnf.gotmpl",
+			},
+		},
+		"MariaDB": {
+			conf: &service.DatabaseConfig{Service: "legacy", System: service.MariaDB, Settings: settings},
+			want: &service.File{
+				Type:            service.ConfigFile,
+				PathOnHost:      "/out/legacy/my.cnf",
+				PathInContainer: "/etc/mysql/conf.d/app.cnf",
+				TemplatePath:    "/mysql/my.cnf.gotmpl",
+			},
+		},
+		"PostgreSQL": {
+			conf:     &service.DatabaseConfig{Service: "analytics", System: service.PostgreSQL, Settings: settings},
+			want:     nil,
+			wantArgs: []string{"-c", "max_connections=200"},
+		},
+		"PostgreSQL with container port": {
+			conf:     &service.DatabaseConfig{System: service.PostgreSQL, ContainerPort: 6432, Settings: settings},
+			want:     nil,
+			wantArgs: []string{"-c", "max_connections=200", "-c", "port=6432"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if tc.want != nil {
+				tc.want.TemplateData = tc.conf
+			}
+
+			if diff := cmp.Diff(tc.want, tc.conf.ConfigFile("/out")); diff != "" {
+				t.Errorf("ConfigFile() mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantArgs, tc.conf.ServerArgs()); diff != "" {
+				t.Errorf("ServerArgs() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestDatabaseConfig_ValidateCorrectInput(t *testing.T) {
 	db := service.DatabaseConfig{
 		System: service.MySQL,
@@ -160,6 +526,38 @@ func TestDatabaseConfig_ValidateCorrectInput(t *testing.T) {
 	failTestOnErrorsOnCorrectInput(errs, t)
 }
 
+func TestDatabaseConfig_TargetPort(t *testing.T) {
+	tests := map[string]struct {
+		input *service.DatabaseConfig
+		want  int
+	}{
+		"default port of mysql": {
+			input: &service.DatabaseConfig{System: service.MySQL, Port: 3307},
+			want:  3306,
+		},
+		"default port of postgresql": {
+			input: &service.DatabaseConfig{System: service.PostgreSQL, Port: 5433},
+			want:  5432,
+		},
+		"container port": {
+			input: &service.DatabaseConfig{
+				System:        service.PostgreSQL,
+				Port:          5433,
+				ContainerPort: 5433,
+			},
+			want: 5433,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.input.TargetPort(); got != tc.want {
+				t.Fatalf("expected: %d, got: %d", tc.want, got)
+			}
+		})
+	}
+}
+
 func TestDatabaseConfig_Environment(t *testing.T) {
 	tests := map[string]struct {
 		conf *service.DatabaseConfig
@@ -201,6 +599,25 @@ func TestDatabaseConfig_Environment(t *testing.T) {
 				"POSTGRES_PASSWORD": "test-password",
 			},
 		},
+		"MariaDB": {
+			conf: &service.DatabaseConfig{
+				System:  service.MariaDB,
+				Version: "10.5",
+				Name:    "test-db",
+				Port:    3306,
+				Credentials: service.Credentials{
+					Username:     "test-user",
+					Password:     "test-password",
+					RootPassword: "test-root-password",
+				},
+			},
+			want: map[string]string{
+				"MARIADB_USER":          "test-user",
+				"MARIADB_DATABASE":      "test-db",
+				"MARIADB_ROOT_PASSWORD": "test-root-password",
+				"MARIADB_PASSWORD":      "test-password",
+			},
+		},
 		"unknown": {
 			conf: &service.DatabaseConfig{
 				System: service.SupportedSystem("unknown"),
@@ -219,3 +636,39 @@ func TestDatabaseConfig_Environment(t *testing.T) {
 		})
 	}
 }
+
+func TestDatabaseConfig_ServerSettings(t *testing.T) {
+	tests := map[string]struct {
+		input *service.DatabaseConfig
+		want  map[string]string
+	}{
+		"without settings": {
+			input: &service.DatabaseConfig{System: service.MySQL},
+			want:  nil,
+		},
+		"default container port": {
+			input: &service.DatabaseConfig{
+				System:        service.PostgreSQL,
+				ContainerPort: 5432,
+				Settings:      map[string]string{"max_connections": "200"},
+			},
+			want: map[string]string{"max_connections": "200"},
+		},
+		"container port": {
+			input: &service.DatabaseConfig{
+				System:        service.PostgreSQL,
+				ContainerPort: 6432,
+				Settings:      map[string]string{"max_connections": "200"},
+			},
+			want: map[string]string{"max_connections": "200", "port": "6432"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.input.ServerSettings()); diff != "" {
+				t.Errorf("ServerSettings() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
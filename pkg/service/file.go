@@ -19,6 +19,8 @@ type File struct {
 	PathOnHost      string
 	PathInContainer string
 	TemplatePath    string
+	// TemplateData is passed to the template instead of FullConfig if it is set
+	TemplateData interface{}
 }
 
 // GetTemplatePath returns path to template from which resulting service file can be rendered
@@ -26,6 +28,11 @@ func (f *File) GetTemplatePath() string {
 	return f.TemplatePath
 }
 
+// GetTemplateData returns data with which template is executed. Nil means that FullConfig is used
+func (f *File) GetTemplateData() interface{} {
+	return f.TemplateData
+}
+
 // GetOutputPath returns path to which resulting service file will be rendered
 func (f *File) GetOutputPath() string {
 	return f.PathOnHost
@@ -21,6 +21,19 @@ func TestFile_GetTemplatePath(t *testing.T) {
 	}
 }
 
+func TestFile_GetTemplateData(t *testing.T) {
+	db := &service.DatabaseConfig{System: service.MySQL}
+	file := service.File{TemplatePath: "/mysql/my.cnf.gotmpl", TemplateData: db}
+
+	if got := file.GetTemplateData(); got != db {
+		t.Fatalf("Incorrect template data: want %v got %v", db, got)
+	}
+
+	if got := (&service.File{}).GetTemplateData(); got != nil {
+		t.Fatalf("Incorrect template data: want nil got %v", got)
+	}
+}
+
 func TestFile_GetOutputPath(t *testing.T) {
 	file := service.File{
 		PathOnHost:   "/host/Dockerfile",
@@ -0,0 +1,91 @@
+package service
+
+import "fmt"
+
+// MongoDBPort is the port MongoDB listens on inside the container
+const MongoDBPort = 27017
+
+// MongoDBDataPath is the path to MongoDB data inside the container
+const MongoDBDataPath = "/data/db"
+
+// MongoDBConfig is a user-defined config for MongoDB
+type MongoDBConfig struct {
+	Version     string
+	Port        int `yaml:",omitempty"`
+	PortBinding `yaml:",inline"`
+	Name        string `yaml:",omitempty"`
+	Username    string `yaml:",omitempty"`
+	Password    string `yaml:",omitempty"`
+}
+
+// FillDefaultsIfNotSet fills default MongoDB parameters if they are not present
+func (m *MongoDBConfig) FillDefaultsIfNotSet() {
+	if m.Version == "" {
+		m.Version = "4.4"
+	}
+
+	if m.Port == 0 {
+		m.Port = MongoDBPort
+	}
+}
+
+// Validate validates MongoDB parameters
+func (m *MongoDBConfig) Validate() error {
+	errors := &ValidationErrors{}
+
+	if m.Version == "" {
+		errors.Add("MongoDB version is required")
+	}
+
+	if m.Port == 0 {
+		errors.Add("MongoDB port is required")
+	}
+
+	m.PortBinding.validate("MongoDB", errors)
+
+	if m.Username != "" && m.Password == "" {
+		errors.Add("MongoDB password is required when username is set")
+	}
+
+	if m.Password != "" && m.Username == "" {
+		errors.Add("MongoDB username is required when password is set")
+	}
+
+	if errors.IsEmpty() {
+		return nil
+	}
+
+	return errors
+}
+
+// Environment returns a collection of environment variables which initialize MongoDB on the first start
+func (m *MongoDBConfig) Environment() map[string]string {
+	env := map[string]string{}
+
+	if m.Username != "" {
+		env["MONGO_INITDB_ROOT_USERNAME"] = m.Username
+	}
+
+	if m.Password != "" {
+		env["MONGO_INITDB_ROOT_PASSWORD"] = m.Password
+	}
+
+	if m.Name != "" {
+		env["MONGO_INITDB_DATABASE"] = m.Name
+	}
+
+	return env
+}
+
+func (m *MongoDBConfig) String() string {
+	return fmt.Sprintf(
+		"MongoDBConfig{Version: %s, Port: %d, HostIP: %s, ExposeOnly: %t, Name: %s, Username: %s, Password: %s}",
+		m.Version,
+		m.Port,
+		m.HostIP,
+		m.ExposeOnly,
+		m.Name,
+		m.Username,
+		maskSecret(m.Password),
+	)
+}
@@ -0,0 +1,127 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/Bocmah/phpdocker-gen/pkg/service"
+)
+
+func TestMongoDB_FillDefaultsIfNotSet(t *testing.T) {
+	mongo := service.MongoDBConfig{}
+
+	mongo.FillDefaultsIfNotSet()
+
+	want := service.MongoDBConfig{
+		Version: "4.4",
+		Port:    27017,
+	}
+
+	if mongo != want {
+		t.Errorf("Incorrect defaults, want %v, got %v", want, mongo)
+	}
+}
+
+func TestMongoDB_ValidateIncorrectInput(t *testing.T) {
+	tests := map[string]struct {
+		conf     service.MongoDBConfig
+		wantErrs []string
+	}{
+		"empty": {
+			conf: service.MongoDBConfig{},
+			wantErrs: []string{
+				"MongoDB version is required",
+				"MongoDB port is required",
+			},
+		},
+		"username without password": {
+			conf: service.MongoDBConfig{Version: "4.4", Port: 27017, Username: "root"},
+			wantErrs: []string{
+				"MongoDB password is required when username is set",
+			},
+		},
+		"host IP with expose-only port": {
+			conf: service.MongoDBConfig{
+				Version:     "4.4",
+				Port:        27017,
+				PortBinding: service.PortBinding{HostIP: "::1", ExposeOnly: true},
+			},
+			wantErrs: []string{
+				"MongoDB host IP can not be set when port is expose-only",
+			},
+		},
+		"password without username": {
+			conf: service.MongoDBConfig{Version: "4.4", Port: 27017, Password: "secret"},
+			wantErrs: []string{
+				"MongoDB username is required when password is set",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs := tc.conf.Validate()
+
+			if errs != nil {
+				res := validationResult{
+					wantErrs:     tc.wantErrs,
+					actualErrs:   errs,
+					validatedVal: tc.conf,
+				}
+
+				failTestOnUnspottedError(res, t)
+			} else {
+				t.Errorf("Did not return any errors for value %v", tc.conf)
+			}
+		})
+	}
+}
+
+func TestMongoDB_ValidateCorrectInput(t *testing.T) {
+	mongo := service.MongoDBConfig{Version: "4.4", Port: 27017, Username: "root", Password: "secret"}
+
+	errs := mongo.Validate()
+
+	failTestOnErrorsOnCorrectInput(errs, t)
+}
+
+func TestMongoDB_Environment(t *testing.T) {
+	tests := map[string]struct {
+		conf service.MongoDBConfig
+		want map[string]string
+	}{
+		"defaults": {
+			conf: service.MongoDBConfig{Version: "4.4", Port: 27017},
+			want: map[string]string{},
+		},
+		"all parameters": {
+			conf: service.MongoDBConfig{Name: "app", Username: "root", Password: "secret"},
+			want: map[string]string{
+				"MONGO_INITDB_ROOT_USERNAME": "root",
+				"MONGO_INITDB_ROOT_PASSWORD": "secret",
+				"MONGO_INITDB_DATABASE":      "app",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := tc.conf.Environment()
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("conf.Environment() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMongoDB_String(t *testing.T) {
+	mongo := &service.MongoDBConfig{Version: "4.4", Port: 27017, Username: "root", Password: "secret"}
+
+	want := "MongoDBConfig{Version: 4.4, Port: 27017, HostIP: , ExposeOnly: false, Name: , Username: root, Password: ***}"
+
+	if got := mongo.String(); got != want {
+		t.Errorf("String() got %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"fmt"
+	"net"
+	"sort"
+)
+
+// supportedNetworkDrivers are drivers of networks which services can be attached to
+var supportedNetworkDrivers = []string{"bridge", "overlay", "macvlan"}
+
+// IsSupportedNetworkDriver determines whether services can be attached to the network with the driver
+func IsSupportedNetworkDriver(driver string) bool {
+	return contains(supportedNetworkDrivers, driver)
+}
+
+// NetworkConfig is a user-defined network. Declared networks replace the default network of the app
+type NetworkConfig struct {
+	Name   string
+	Driver string `yaml:",omitempty"`
+	// External network is created outside of the project (e.g. a reverse-proxy network shared by several apps)
+	External bool   `yaml:",omitempty"`
+	Subnet   string `yaml:",omitempty"`
+	Gateway  string `yaml:",omitempty"`
+	IPRange  string `yaml:"ipRange,omitempty"`
+	// Services are keyed by docker-compose service name (e.g. webserver or db). All services join the network when
+	// there are none
+	Services map[string]*NetworkMemberConfig `yaml:",omitempty"`
+}
+
+// NetworkMemberConfig configures a service within the network
+type NetworkMemberConfig struct {
+	// Aliases are additional hostnames of the service within the network
+	Aliases     []string `yaml:",omitempty"`
+	IPv4Address string   `yaml:"ipv4Address,omitempty"`
+}
+
+// FillDefaultsIfNotSet fills default network parameters if they are not present
+func (n *NetworkConfig) FillDefaultsIfNotSet() {
+	if n.Driver == "" && !n.External {
+		n.Driver = "bridge"
+	}
+}
+
+// Validate validates network parameters
+func (n *NetworkConfig) Validate() error {
+	errors := &ValidationErrors{}
+
+	if n.Name == "" {
+		errors.Add("Network name is required")
+	}
+
+	if n.Driver != "" && !contains(supportedNetworkDrivers, n.Driver) {
+		errors.Add(fmt.Sprintf("Network %s driver %s is not supported", n.Name, n.Driver))
+	}
+
+	if n.External && n.Driver != "" {
+		errors.Add(fmt.Sprintf("External network %s can not have driver", n.Name))
+	}
+
+	if n.External && (n.Subnet != "" || n.Gateway != "" || n.IPRange != "") {
+		errors.Add(fmt.Sprintf("External network %s can not have subnet, gateway or IP range", n.Name))
+	}
+
+	n.validateAddresses(errors)
+
+	if errors.IsEmpty() {
+		return nil
+	}
+
+	return errors
+}
+
+func (n *NetworkConfig) validateAddresses(errors *ValidationErrors) {
+	var subnet *net.IPNet
+
+	if n.Subnet != "" {
+		_, parsed, err := net.ParseCIDR(n.Subnet)
+
+		if err != nil {
+			errors.Add(fmt.Sprintf("Network %s subnet %s is invalid", n.Name, n.Subnet))
+		}
+
+		subnet = parsed
+	}
+
+	if n.Gateway != "" {
+		gateway := net.ParseIP(n.Gateway)
+
+		switch {
+		case gateway == nil:
+			errors.Add(fmt.Sprintf("Network %s gateway %s is invalid", n.Name, n.Gateway))
+		case n.Subnet == "":
+			errors.Add(fmt.Sprintf("Network %s gateway requires subnet", n.Name))
+		case subnet != nil && !subnet.Contains(gateway):
+			errors.Add(fmt.Sprintf("Network %s gateway %s is outside of subnet %s", n.Name, n.Gateway, n.Subnet))
+		}
+	}
+
+	if n.IPRange != "" {
+		ip, _, err := net.ParseCIDR(n.IPRange)
+
+		switch {
+		case err != nil:
+			errors.Add(fmt.Sprintf("Network %s IP range %s is invalid", n.Name, n.IPRange))
+		case n.Subnet == "":
+			errors.Add(fmt.Sprintf("Network %s IP range requires subnet", n.Name))
+		case subnet != nil && !subnet.Contains(ip):
+			errors.Add(fmt.Sprintf("Network %s IP range %s is outside of subnet %s", n.Name, n.IPRange, n.Subnet))
+		}
+	}
+
+	for _, name := range n.memberNames() {
+		address := n.Services[name].address()
+
+		if address == "" {
+			continue
+		}
+
+		ip := net.ParseIP(address)
+
+		switch {
+		case ip == nil || ip.To4() == nil:
+			errors.Add(fmt.Sprintf("Network %s address %s of %s is invalid", n.Name, address, name))
+		case n.Subnet == "" && !n.External:
+			errors.Add(fmt.Sprintf("Network %s address of %s requires subnet", n.Name, name))
+		case subnet != nil && !subnet.Contains(ip):
+			errors.Add(fmt.Sprintf("Network %s address %s of %s is outside of subnet %s", n.Name, address, name, n.Subnet))
+		}
+	}
+}
+
+// Member returns config of the service within the network. False is returned when the service does not join it
+func (n *NetworkConfig) Member(service string) (*NetworkMemberConfig, bool) {
+	if len(n.Services) == 0 {
+		return &NetworkMemberConfig{}, true
+	}
+
+	member, ok := n.Services[service]
+
+	if !ok {
+		return nil, false
+	}
+
+	if member == nil {
+		member = &NetworkMemberConfig{}
+	}
+
+	return member, true
+}
+
+// memberNames returns names of the services listed in the network in alphabetical order
+func (n *NetworkConfig) memberNames() []string {
+	names := make([]string, 0, len(n.Services))
+
+	for name := range n.Services {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func (m *NetworkMemberConfig) address() string {
+	if m == nil {
+		return ""
+	}
+
+	return m.IPv4Address
+}
+
+func (n *NetworkConfig) String() string {
+	return fmt.Sprintf(
+		"NetworkConfig{Name: %s, Driver: %s, External: %t, Subnet: %s, Gateway: %s, IPRange: %s, Services: %v}",
+		n.Name,
+		n.Driver,
+		n.External,
+		n.Subnet,
+		n.Gateway,
+		n.IPRange,
+		n.Services,
+	)
+}
+
+// validateNetworks ensures that networks have unique names, their services are present in the config and services
+// share a network with the services they connect to. Services which are not listed in any network are attached to the
+// implicit default network only
+func validateNetworks(networks []*NetworkConfig, services *ServicesConfig) *ValidationErrors {
+	errors := &ValidationErrors{}
+	declared := map[string]bool{}
+	names := services.ServiceNames()
+
+	for _, network := range networks {
+		errors.addFrom(network.Validate())
+
+		if declared[network.Name] && network.Name != "" {
+			errors.Add(fmt.Sprintf("Network %s is declared more than once", network.Name))
+		}
+
+		declared[network.Name] = true
+
+		for _, name := range network.memberNames() {
+			if !contains(names, name) {
+				errors.Add(fmt.Sprintf("Network %s has unknown service %s", network.Name, name))
+			}
+		}
+	}
+
+	if len(networks) == 0 {
+		return errors
+	}
+
+	dependencies := services.Dependencies()
+	connecting := make([]string, 0, len(dependencies))
+
+	for name := range dependencies {
+		connecting = append(connecting, name)
+	}
+
+	sort.Strings(connecting)
+
+	for _, name := range connecting {
+		for _, dependency := range dependencies[name] {
+			if !shareNetwork(networks, name, dependency) {
+				errors.Add(fmt.Sprintf("Services %s and %s do not share a network", name, dependency))
+			}
+		}
+	}
+
+	return errors
+}
+
+// shareNetwork determines whether both services are attached to one of the networks. Services which are not attached
+// to any of them share the implicit default network
+func shareNetwork(networks []*NetworkConfig, first, second string) bool {
+	firstAttached, secondAttached := false, false
+
+	for _, network := range networks {
+		_, firstOk := network.Member(first)
+		_, secondOk := network.Member(second)
+
+		if firstOk && secondOk {
+			return true
+		}
+
+		firstAttached = firstAttached || firstOk
+		secondAttached = secondAttached || secondOk
+	}
+
+	return !firstAttached && !secondAttached
+}
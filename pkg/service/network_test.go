@@ -0,0 +1,216 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/Bocmah/phpdocker-gen/pkg/service"
+)
+
+func TestNetwork_FillDefaultsIfNotSet(t *testing.T) {
+	tests := map[string]struct {
+		conf service.NetworkConfig
+		want service.NetworkConfig
+	}{
+		"empty": {
+			conf: service.NetworkConfig{Name: "backend"},
+			want: service.NetworkConfig{Name: "backend", Driver: "bridge"},
+		},
+		"external": {
+			conf: service.NetworkConfig{Name: "proxy", External: true},
+			want: service.NetworkConfig{Name: "proxy", External: true},
+		},
+		"driver is set": {
+			conf: service.NetworkConfig{Name: "backend", Driver: "overlay"},
+			want: service.NetworkConfig{Name: "backend", Driver: "overlay"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.conf.FillDefaultsIfNotSet()
+
+			if diff := cmp.Diff(tc.want, tc.conf); diff != "" {
+				t.Errorf("FillDefaultsIfNotSet() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNetwork_ValidateIncorrectInput(t *testing.T) {
+	tests := map[string]struct {
+		conf     service.NetworkConfig
+		wantErrs []string
+	}{
+		"empty": {
+			conf:     service.NetworkConfig{},
+			wantErrs: []string{"Network name is required"},
+		},
+		"unsupported driver": {
+			conf:     service.NetworkConfig{Name: "backend", Driver: "host"},
+			wantErrs: []string{"Network backend driver host is not supported"},
+		},
+		"external with driver and subnet": {
+			conf: service.NetworkConfig{Name: "proxy", Driver: "bridge", External: true, Subnet: "172.28.0.0/16"},
+			wantErrs: []string{
+				"External network proxy can not have driver",
+				"External network proxy can not have subnet, gateway or IP range",
+			},
+		},
+		"invalid addresses": {
+			conf: service.NetworkConfig{
+				Name:    "backend",
+				Driver:  "bridge",
+				Subnet:  "172.28.0.0",
+				Gateway: "172.28.0.256",
+				IPRange: "172.28.5.0",
+			},
+			wantErrs: []string{
+				"Network backend subnet 172.28.0.0 is invalid",
+				"Network backend gateway 172.28.0.256 is invalid",
+				"Network backend IP range 172.28.5.0 is invalid",
+			},
+		},
+		"addresses without subnet": {
+			conf: service.NetworkConfig{
+				Name:     "backend",
+				Driver:   "bridge",
+				Gateway:  "172.28.0.1",
+				IPRange:  "172.28.5.0/24",
+				Services: map[string]*service.NetworkMemberConfig{"db": {IPv4Address: "172.28.5.10"}},
+			},
+			wantErrs: []string{
+				"Network backend gateway requires subnet",
+				"Network backend IP range requires subnet",
+				"Network backend address of db requires subnet",
+			},
+		},
+		"addresses outside of subnet": {
+			conf: service.NetworkConfig{
+				Name:     "backend",
+				Driver:   "bridge",
+				Subnet:   "172.28.0.0/16",
+				Gateway:  "172.29.0.1",
+				IPRange:  "172.29.5.0/24",
+				Services: map[string]*service.NetworkMemberConfig{"db": {IPv4Address: "172.29.5.10"}},
+			},
+			wantErrs: []string{
+				"Network backend gateway 172.29.0.1 is outside of subnet 172.28.0.0/16",
+				"Network backend IP range 172.29.5.0/24 is outside of subnet 172.28.0.0/16",
+				"Network backend address 172.29.5.10 of db is outside of subnet 172.28.0.0/16",
+			},
+		},
+		"invalid service address": {
+			conf: service.NetworkConfig{
+				Name:     "backend",
+				Driver:   "bridge",
+				Subnet:   "172.28.0.0/16",
+				Services: map[string]*service.NetworkMemberConfig{"db": {IPv4Address: "::1"}},
+			},
+			wantErrs: []string{"Network backend address ::1 of db is invalid"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs := tc.conf.Validate()
+
+			if errs != nil {
+				res := validationResult{
+					wantErrs:     tc.wantErrs,
+					actualErrs:   errs,
+					validatedVal: tc.conf,
+				}
+
+				failTestOnUnspottedError(res, t)
+			} else {
+				t.Errorf("Did not return any errors for value %v", tc.conf)
+			}
+		})
+	}
+}
+
+func TestNetwork_ValidateCorrectInput(t *testing.T) {
+	tests := map[string]service.NetworkConfig{
+		"bridge": {
+			Name:    "backend",
+			Driver:  "bridge",
+			Subnet:  "172.28.0.0/16",
+			Gateway: "172.28.0.1",
+			IPRange: "172.28.5.0/24",
+			Services: map[string]*service.NetworkMemberConfig{
+				"db":        {IPv4Address: "172.28.5.10"},
+				"webserver": nil,
+			},
+		},
+		"external with service address": {
+			Name:     "proxy",
+			External: true,
+			Services: map[string]*service.NetworkMemberConfig{
+				"webserver": {Aliases: []string{"app.test"}, IPv4Address: "172.30.0.10"},
+			},
+		},
+	}
+
+	for name, conf := range tests {
+		t.Run(name, func(t *testing.T) {
+			failTestOnErrorsOnCorrectInput(conf.Validate(), t)
+		})
+	}
+}
+
+func TestNetwork_Member(t *testing.T) {
+	network := service.NetworkConfig{
+		Name: "proxy",
+		Services: map[string]*service.NetworkMemberConfig{
+			"webserver": {Aliases: []string{"app.test"}},
+			"php-fpm":   nil,
+		},
+	}
+
+	tests := map[string]struct {
+		network service.NetworkConfig
+		service string
+		want    *service.NetworkMemberConfig
+		wantOk  bool
+	}{
+		"listed service": {
+			network: network,
+			service: "webserver",
+			want:    &service.NetworkMemberConfig{Aliases: []string{"app.test"}},
+			wantOk:  true,
+		},
+		"listed service without options": {
+			network: network,
+			service: "php-fpm",
+			want:    &service.NetworkMemberConfig{},
+			wantOk:  true,
+		},
+		"not listed service": {
+			network: network,
+			service: "db",
+			wantOk:  false,
+		},
+		"network without services": {
+			network: service.NetworkConfig{Name: "backend"},
+			service: "db",
+			want:    &service.NetworkMemberConfig{},
+			wantOk:  true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := tc.network.Member(tc.service)
+
+			if ok != tc.wantOk {
+				t.Fatalf("expected ok to be %t, got %t", tc.wantOk, ok)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Member() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -2,18 +2,22 @@ package service
 
 import "fmt"
 
+// PHPFPMPort is the port php-fpm listens on inside the container unless another FastCGI pass port is set
+const PHPFPMPort = 9000
+
 // NginxConfig is a user-defined config for nginx
 type NginxConfig struct {
-	HTTPPort   int      `yaml:"httpPort"`
-	HTTPSPort  int      `yaml:"httpsPort"`
-	ServerName string   `yaml:"serverName"`
-	FastCGI    *FastCGI `yaml:"fastCGI"`
+	HTTPPort    int `yaml:"httpPort,omitempty"`
+	HTTPSPort   int `yaml:"httpsPort,omitempty"`
+	PortBinding `yaml:",inline"`
+	ServerName  string   `yaml:"serverName"`
+	FastCGI     *FastCGI `yaml:"fastCGI,omitempty"`
 }
 
 // FastCGI is settings for a FastCGI protocol
 type FastCGI struct {
-	PassPort           int `yaml:"passPort"`
-	ReadTimeoutSeconds int `yaml:"readTimeoutSeconds"`
+	PassPort           int `yaml:"passPort,omitempty"`
+	ReadTimeoutSeconds int `yaml:"readTimeoutSeconds,omitempty"`
 }
 
 // FillDefaultsIfNotSet fills default nginx parameters if they are not present
@@ -31,7 +35,7 @@ func (n *NginxConfig) FillDefaultsIfNotSet() {
 	}
 
 	if n.FastCGI.PassPort == 0 {
-		n.FastCGI.PassPort = 9000
+		n.FastCGI.PassPort = PHPFPMPort
 	}
 
 	if n.FastCGI.ReadTimeoutSeconds == 0 {
@@ -47,6 +51,8 @@ func (n *NginxConfig) Validate() error {
 		errors.Add("nginx port is required")
 	}
 
+	n.PortBinding.validate("nginx", errors)
+
 	if n.FastCGI == nil {
 		errors.Add("nginx FastCGI pass port is required", "nginx FastCGI read timeout is required")
 	} else if n.FastCGI.PassPort == 0 {
@@ -64,9 +70,11 @@ func (n *NginxConfig) Validate() error {
 
 func (n *NginxConfig) String() string {
 	return fmt.Sprintf(
-		"NginxConfig{HTTPPort: %d, HTTPSPort: %d, ServerName: %s, FastCGI: %v}",
+		"NginxConfig{HTTPPort: %d, HTTPSPort: %d, HostIP: %s, ExposeOnly: %t, ServerName: %s, FastCGI: %v}",
 		n.HTTPPort,
 		n.HTTPSPort,
+		n.HostIP,
+		n.ExposeOnly,
 		n.ServerName,
 		n.FastCGI,
 	)
@@ -40,6 +40,17 @@ func TestNginx_ValidateIncorrectInput(t *testing.T) {
 				"nginx FastCGI read timeout is required",
 			},
 		},
+		"with invalid host IP": {
+			conf: &service.NginxConfig{
+				HTTPPort:    80,
+				HTTPSPort:   443,
+				PortBinding: service.PortBinding{HostIP: "0.0.0"},
+				FastCGI:     &service.FastCGI{PassPort: 9000, ReadTimeoutSeconds: 60},
+			},
+			wantErrs: []string{
+				"nginx host IP 0.0.0 is invalid",
+			},
+		},
 		"without FastCGI pass port": {
 			conf: &service.NginxConfig{
 				HTTPPort:   80,
@@ -1,11 +1,64 @@
 package service
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// peclExtensions are extensions which are installed from PECL instead of being compiled from PHP sources
+var peclExtensions = []string{"apcu", "igbinary", "mongodb", "redis", "xdebug"}
+
+// peclRelease is the last release of PECL extension which supports PHP versions below the given one
+type peclRelease struct {
+	below   phpVersion
+	version string
+}
+
+// peclReleases pin PECL extensions for older PHP versions since their latest releases require a newer one. The latest
+// release is installed when no pin matches. Releases are sorted by PHP version
+var peclReleases = map[string][]peclRelease{
+	"apcu":     {{below: phpVersion{8, 0}, version: "5.1.23"}},
+	"igbinary": {{below: phpVersion{8, 0}, version: "3.2.16"}},
+	"mongodb": {
+		{below: phpVersion{8, 0}, version: "1.16.2"},
+		{below: phpVersion{8, 1}, version: "1.19.4"},
+	},
+	"redis":  {{below: phpVersion{8, 0}, version: "5.3.7"}},
+	"xdebug": {{below: phpVersion{8, 0}, version: "3.1.6"}},
+}
+
+var phpVersionRegexp = regexp.MustCompile(`^(\d+)\.(\d+)`)
+
+// phpVersion is major and minor version of PHP
+type phpVersion struct {
+	major int
+	minor int
+}
+
+// parsePHPVersion reads major and minor version from the tag of PHP image (e.g. 7.4 or 8.1.2-rc). False is returned
+// for tags without them (e.g. latest)
+func parsePHPVersion(version string) (phpVersion, bool) {
+	m := phpVersionRegexp.FindStringSubmatch(version)
+
+	if m == nil {
+		return phpVersion{}, false
+	}
+
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+
+	return phpVersion{major: major, minor: minor}, true
+}
+
+func (v phpVersion) less(other phpVersion) bool {
+	return v.major < other.major || (v.major == other.major && v.minor < other.minor)
+}
 
 // PHPConfig is a user-defined config for PHP
 type PHPConfig struct {
 	Version    string
-	Extensions []string
+	Extensions []string `yaml:",omitempty"`
 }
 
 // FillDefaultsIfNotSet fills default PHP parameters if they are not present
@@ -19,14 +72,75 @@ func (p *PHPConfig) FillDefaultsIfNotSet() {
 	}
 }
 
+// AddExtension adds extension if it is not present yet
+func (p *PHPConfig) AddExtension(ext string) {
+	if !contains(p.Extensions, ext) {
+		p.Extensions = append(p.Extensions, ext)
+	}
+}
+
+// HasExtension determines whether extension is present
+func (p *PHPConfig) HasExtension(ext string) bool {
+	return contains(p.Extensions, ext)
+}
+
 // AddDatabaseExtension adds a specific PDO extension for given database system
 func (p *PHPConfig) AddDatabaseExtension(db SupportedSystem) {
 	switch db {
-	case MySQL:
-		p.Extensions = append(p.Extensions, "pdo_mysql")
+	case MySQL, MariaDB:
+		p.AddExtension("pdo_mysql")
 	case PostgreSQL:
-		p.Extensions = append(p.Extensions, "pdo_pgsql")
+		p.AddExtension("pdo_pgsql")
+	}
+}
+
+// CoreExtensions returns extensions which are compiled from PHP sources
+func (p *PHPConfig) CoreExtensions() []string {
+	var core []string
+
+	for _, ext := range p.Extensions {
+		if !contains(peclExtensions, ext) {
+			core = append(core, ext)
+		}
+	}
+
+	return core
+}
+
+// PECLExtensions returns extensions which are installed from PECL
+func (p *PHPConfig) PECLExtensions() []string {
+	var pecl []string
+
+	for _, ext := range p.Extensions {
+		if contains(peclExtensions, ext) {
+			pecl = append(pecl, ext)
+		}
 	}
+
+	return pecl
+}
+
+// PECLPackages returns PECL packages of extensions which are installed from PECL. Extensions are pinned to the last
+// release which supports the PHP version in use (e.g. redis-5.3.7 for PHP 7.4)
+func (p *PHPConfig) PECLPackages() []string {
+	version, versionOk := parsePHPVersion(p.Version)
+
+	var packages []string
+
+	for _, ext := range p.PECLExtensions() {
+		pkg := ext
+
+		for _, release := range peclReleases[ext] {
+			if versionOk && version.less(release.below) {
+				pkg = ext + "-" + release.version
+				break
+			}
+		}
+
+		packages = append(packages, pkg)
+	}
+
+	return packages
 }
 
 // Validate validates PHP parameters
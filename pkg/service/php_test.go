@@ -56,6 +56,16 @@ func TestPHP_AddDatabaseExtension(t *testing.T) {
 	}
 }
 
+func TestPHP_AddDatabaseExtension_MariaDB(t *testing.T) {
+	php := service.PHPConfig{Version: "7.4", Extensions: []string{}}
+
+	php.AddDatabaseExtension(service.MariaDB)
+
+	if want := []string{"pdo_mysql"}; !reflect.DeepEqual(php.Extensions, want) {
+		t.Errorf("Incorrect extensions, want %v, got %v", want, php.Extensions)
+	}
+}
+
 func TestPHP_ValidateIncorrectInput(t *testing.T) {
 	php := service.PHPConfig{}
 
@@ -91,3 +101,77 @@ func TestPHP_IsEmpty(t *testing.T) {
 		t.Errorf("Failed to assert that non-empty PHPConfig service is actually non-empty")
 	}
 }
+
+func TestPHP_AddExtension(t *testing.T) {
+	php := service.PHPConfig{Version: "7.4", Extensions: []string{"mbstring", "pdo_mysql"}}
+
+	php.AddExtension("redis")
+	php.AddExtension("redis")
+	php.AddDatabaseExtension(service.MySQL)
+
+	want := []string{"mbstring", "pdo_mysql", "redis"}
+
+	if !reflect.DeepEqual(php.Extensions, want) {
+		t.Errorf("PHPConfig extensions got %v, want %v", php.Extensions, want)
+	}
+}
+
+func TestPHP_HasExtension(t *testing.T) {
+	php := service.PHPConfig{Version: "7.4", Extensions: []string{"mbstring", "pdo_mysql"}}
+
+	if !php.HasExtension("pdo_mysql") {
+		t.Errorf("Failed to find extension pdo_mysql in %v", php.Extensions)
+	}
+
+	if php.HasExtension("redis") {
+		t.Errorf("Found extension redis which is not present in %v", php.Extensions)
+	}
+}
+
+func TestPHP_CoreAndPECLExtensions(t *testing.T) {
+	php := service.PHPConfig{Version: "7.4", Extensions: []string{"mbstring", "redis", "gd", "xdebug"}}
+
+	if want := []string{"mbstring", "gd"}; !reflect.DeepEqual(php.CoreExtensions(), want) {
+		t.Errorf("CoreExtensions() got %v, want %v", php.CoreExtensions(), want)
+	}
+
+	if want := []string{"redis", "xdebug"}; !reflect.DeepEqual(php.PECLExtensions(), want) {
+		t.Errorf("PECLExtensions() got %v, want %v", php.PECLExtensions(), want)
+	}
+}
+
+func TestPHP_PECLPackages(t *testing.T) {
+	extensions := []string{"mbstring", "mongodb", "redis", "xdebug"}
+
+	tests := map[string]struct {
+		version string
+		want    []string
+	}{
+		"PHP 7": {
+			version: "7.4",
+			want:    []string{"mongodb-1.16.2", "redis-5.3.7", "xdebug-3.1.6"},
+		},
+		"PHP 8.0 with patch version": {
+			version: "8.0.30",
+			want:    []string{"mongodb-1.19.4", "redis", "xdebug"},
+		},
+		"recent PHP": {
+			version: "8.2",
+			want:    []string{"mongodb", "redis", "xdebug"},
+		},
+		"tag without version": {
+			version: "latest",
+			want:    []string{"mongodb", "redis", "xdebug"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			php := service.PHPConfig{Version: tc.version, Extensions: extensions}
+
+			if got := php.PECLPackages(); !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("PECLPackages() got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
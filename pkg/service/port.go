@@ -0,0 +1,25 @@
+package service
+
+import (
+	"fmt"
+	"net"
+)
+
+// PortBinding controls how the port of the service is published on the host
+type PortBinding struct {
+	// HostIP restricts the published port to a single host interface (e.g. 127.0.0.1)
+	HostIP string `yaml:"hostIP,omitempty"`
+	// ExposeOnly makes the port reachable only by other services of the project, it is not published on the host
+	ExposeOnly bool `yaml:"exposeOnly,omitempty"`
+}
+
+// validate adds binding errors to the collection. Subject is the name of the service used in error messages
+func (b PortBinding) validate(subject string, errors *ValidationErrors) {
+	if b.HostIP != "" && net.ParseIP(b.HostIP) == nil {
+		errors.Add(fmt.Sprintf("%s host IP %s is invalid", subject, b.HostIP))
+	}
+
+	if b.HostIP != "" && b.ExposeOnly {
+		errors.Add(fmt.Sprintf("%s host IP can not be set when port is expose-only", subject))
+	}
+}
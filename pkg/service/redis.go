@@ -0,0 +1,119 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// RedisPort is the port Redis listens on inside the container
+const RedisPort = 6379
+
+// RedisDataPath is the path to Redis data inside the container
+const RedisDataPath = "/data"
+
+// maxMemoryRegexp matches memory limit in bytes or with one of the units redis-server understands (e.g. 256mb)
+var maxMemoryRegexp = regexp.MustCompile(`^(?i)[0-9]+(k|kb|m|mb|g|gb)?$`)
+
+var maxMemoryPolicies = []string{
+	"noeviction",
+	"allkeys-lru",
+	"allkeys-lfu",
+	"allkeys-random",
+	"volatile-lru",
+	"volatile-lfu",
+	"volatile-random",
+	"volatile-ttl",
+}
+
+// RedisConfig is a user-defined config for Redis
+type RedisConfig struct {
+	Version         string
+	Port            int `yaml:",omitempty"`
+	PortBinding     `yaml:",inline"`
+	Persistence     bool   `yaml:",omitempty"`
+	Password        string `yaml:",omitempty"`
+	MaxMemory       string `yaml:"maxMemory,omitempty"`
+	MaxMemoryPolicy string `yaml:"maxMemoryPolicy,omitempty"`
+}
+
+// FillDefaultsIfNotSet fills default Redis parameters if they are not present
+func (r *RedisConfig) FillDefaultsIfNotSet() {
+	if r.Version == "" {
+		r.Version = "6.0"
+	}
+
+	if r.Port == 0 {
+		r.Port = RedisPort
+	}
+}
+
+// Validate validates Redis parameters
+func (r *RedisConfig) Validate() error {
+	errors := &ValidationErrors{}
+
+	if r.Version == "" {
+		errors.Add("Redis version is required")
+	}
+
+	if r.Port == 0 {
+		errors.Add("Redis port is required")
+	}
+
+	r.PortBinding.validate("Redis", errors)
+
+	if r.MaxMemory != "" && !maxMemoryRegexp.MatchString(r.MaxMemory) {
+		errors.Add(fmt.Sprintf("Redis maxmemory %s is invalid", r.MaxMemory))
+	}
+
+	if r.MaxMemoryPolicy != "" && !contains(maxMemoryPolicies, r.MaxMemoryPolicy) {
+		errors.Add(fmt.Sprintf("Unsupported Redis maxmemory policy %s", r.MaxMemoryPolicy))
+	}
+
+	if r.MaxMemoryPolicy != "" && r.MaxMemory == "" {
+		errors.Add("Redis maxmemory is required when maxmemory policy is set")
+	}
+
+	if errors.IsEmpty() {
+		return nil
+	}
+
+	return errors
+}
+
+// ServerArgs returns arguments for redis-server which apply the config. Nil is returned if defaults of the image
+// are enough
+func (r *RedisConfig) ServerArgs() []string {
+	var args []string
+
+	if r.Persistence {
+		args = append(args, "--appendonly", "yes")
+	}
+
+	if r.Password != "" {
+		args = append(args, "--requirepass", r.Password)
+	}
+
+	if r.MaxMemory != "" {
+		args = append(args, "--maxmemory", r.MaxMemory)
+	}
+
+	if r.MaxMemoryPolicy != "" {
+		args = append(args, "--maxmemory-policy", r.MaxMemoryPolicy)
+	}
+
+	return args
+}
+
+func (r *RedisConfig) String() string {
+	return fmt.Sprintf(
+		"RedisConfig{Version: %s, Port: %d, HostIP: %s, ExposeOnly: %t, Persistence: %t, Password: %s, MaxMemory: %s, MaxMemoryPolicy: %s}",
+		r.Version,
+		r.Port,
+		r.HostIP,
+		r.ExposeOnly,
+		r.Persistence,
+		maskSecret(r.Password),
+		r.MaxMemory,
+		r.MaxMemoryPolicy,
+	)
+}
@@ -0,0 +1,93 @@
+package service_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Bocmah/phpdocker-gen/pkg/service"
+)
+
+func TestRedis_FillDefaultsIfNotSet(t *testing.T) {
+	redis := service.RedisConfig{}
+
+	redis.FillDefaultsIfNotSet()
+
+	want := service.RedisConfig{
+		Version: "6.0",
+		Port:    6379,
+	}
+
+	if redis != want {
+		t.Errorf("Incorrect defaults, want %v, got %v", want, redis)
+	}
+}
+
+func TestRedis_ValidateIncorrectInput(t *testing.T) {
+	redis := service.RedisConfig{
+		MaxMemory:       "256 megabytes",
+		MaxMemoryPolicy: "lru",
+		PortBinding:     service.PortBinding{HostIP: "127.0.0.300"},
+	}
+
+	errs := redis.Validate()
+
+	if errs != nil {
+		res := validationResult{
+			wantErrs: []string{
+				"Redis version is required",
+				"Redis port is required",
+				"Redis maxmemory 256 megabytes is invalid",
+				"Unsupported Redis maxmemory policy lru",
+				"Redis host IP 127.0.0.300 is invalid",
+			},
+			actualErrs:   errs,
+			validatedVal: redis,
+		}
+
+		failTestOnUnspottedError(res, t)
+	} else {
+		t.Errorf("Did not return any errors for value %v", redis)
+	}
+}
+
+func TestRedis_ValidateCorrectInput(t *testing.T) {
+	redis := service.RedisConfig{Version: "6.0", Port: 6379, MaxMemory: "256mb", MaxMemoryPolicy: "allkeys-lru"}
+
+	errs := redis.Validate()
+
+	failTestOnErrorsOnCorrectInput(errs, t)
+}
+
+func TestRedis_ServerArgs(t *testing.T) {
+	tests := map[string]struct {
+		input service.RedisConfig
+		want  []string
+	}{
+		"defaults": {
+			input: service.RedisConfig{Version: "6.0", Port: 6379},
+			want:  nil,
+		},
+		"all options": {
+			input: service.RedisConfig{Persistence: true, Password: "secret", MaxMemory: "1gb", MaxMemoryPolicy: "volatile-ttl"},
+			want:  []string{"--appendonly", "yes", "--requirepass", "secret", "--maxmemory", "1gb", "--maxmemory-policy", "volatile-ttl"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.input.ServerArgs(); !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("ServerArgs() got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRedis_String(t *testing.T) {
+	redis := &service.RedisConfig{Version: "6.0", Port: 6379, Password: "secret", MaxMemory: "256mb"}
+
+	want := "RedisConfig{Version: 6.0, Port: 6379, HostIP: , ExposeOnly: false, Persistence: false, Password: ***, MaxMemory: 256mb, MaxMemoryPolicy: }"
+
+	if got := redis.String(); got != want {
+		t.Errorf("String() got %s, want %s", got, want)
+	}
+}
@@ -6,7 +6,7 @@ import "fmt"
 type SupportedService int
 
 func (s SupportedService) String() string {
-	if s < PHP || s > NodeJS {
+	if s < PHP || s > MongoDB {
 		return "Unknown"
 	}
 
@@ -15,11 +15,37 @@ func (s SupportedService) String() string {
 		"Nginx",
 		"Database",
 		"NodeJS",
+		"Redis",
+		"MongoDB",
 	}
 
 	return services[s-1]
 }
 
+// ServiceName returns name of the docker-compose service. Names of database services are returned by
+// DatabaseConfig.ServiceName since a project may have several of them
+func (s SupportedService) ServiceName() string {
+	return fixedServiceNames[s]
+}
+
+// isFixedServiceName determines whether the name of docker-compose service belongs to one of the services other than
+// databases
+func isFixedServiceName(name string) bool {
+	for _, fixed := range fixedServiceNames {
+		if fixed == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ProjectRootPath returns path inside the container to which project root is mounted. Empty string is returned if
+// the service does not mount it
+func (s SupportedService) ProjectRootPath() string {
+	return projectRootPaths[s]
+}
+
 // SupportedServices returns a collection of all supported services
 func SupportedServices() []SupportedService {
 	return []SupportedService{
@@ -27,6 +53,8 @@ func SupportedServices() []SupportedService {
 		Nginx,
 		Database,
 		NodeJS,
+		Redis,
+		MongoDB,
 	}
 }
 
@@ -36,14 +64,50 @@ const (
 	Nginx
 	Database
 	NodeJS
+	Redis
+	MongoDB
 )
 
+// fixedServiceNames maps services to names of their docker-compose services
+var fixedServiceNames = map[SupportedService]string{
+	PHP:     "php-fpm",
+	Nginx:   "webserver",
+	NodeJS:  "nodejs",
+	Redis:   "redis",
+	MongoDB: "mongodb",
+}
+
+// projectRootPaths are paths inside containers to which project root is mounted
+var projectRootPaths = map[SupportedService]string{
+	PHP:    "/var/www",
+	Nginx:  "/var/www",
+	NodeJS: "/opt",
+}
+
 // ServicesConfig contains config for each service
 type ServicesConfig struct {
-	PHP      *PHPConfig
-	Nginx    *NginxConfig
-	Database *DatabaseConfig
-	NodeJS   *NodeJSConfig
+	PHP      *PHPConfig      `yaml:",omitempty"`
+	Nginx    *NginxConfig    `yaml:",omitempty"`
+	Database *DatabaseConfig `yaml:",omitempty"`
+	// Databases are used when the project needs more than one database
+	Databases []*DatabaseConfig `yaml:",omitempty"`
+	NodeJS    *NodeJSConfig     `yaml:",omitempty"`
+	Redis     *RedisConfig      `yaml:",omitempty"`
+	MongoDB   *MongoDBConfig    `yaml:"mongodb,omitempty"`
+}
+
+// AllDatabases returns database from the database key followed by databases from the databases list. Empty configs
+// are skipped
+func (s *ServicesConfig) AllDatabases() []*DatabaseConfig {
+	var all []*DatabaseConfig
+
+	for _, db := range append([]*DatabaseConfig{s.Database}, s.Databases...) {
+		if db != nil && !db.IsEmpty() {
+			all = append(all, db)
+		}
+	}
+
+	return all
 }
 
 // FillDefaultsIfNotSet fills default parameters (if they are not present) for all services in the config
@@ -52,14 +116,36 @@ func (s *ServicesConfig) FillDefaultsIfNotSet() {
 		s.Database.FillDefaultsIfNotSet()
 	}
 
+	for _, db := range s.Databases {
+		if db != nil {
+			db.FillDefaultsIfNotSet()
+		}
+	}
+
 	if s.PHP != nil {
 		s.PHP.FillDefaultsIfNotSet()
 
-		if s.Database != nil {
-			s.PHP.AddDatabaseExtension(s.Database.System)
+		for _, db := range s.AllDatabases() {
+			s.PHP.AddDatabaseExtension(db.System)
+		}
+
+		if s.Redis != nil {
+			s.PHP.AddExtension("redis")
+		}
+
+		if s.MongoDB != nil {
+			s.PHP.AddExtension("mongodb")
 		}
 	}
 
+	if s.Redis != nil {
+		s.Redis.FillDefaultsIfNotSet()
+	}
+
+	if s.MongoDB != nil {
+		s.MongoDB.FillDefaultsIfNotSet()
+	}
+
 	if s.NodeJS != nil {
 		s.NodeJS.FillDefaultsIfNotSet()
 	}
@@ -74,10 +160,11 @@ func (s *ServicesConfig) Validate() error {
 	errors := &ValidationErrors{}
 
 	services := map[SupportedService]Config{
-		PHP:      s.PHP,
-		NodeJS:   s.NodeJS,
-		Nginx:    s.Nginx,
-		Database: s.Database,
+		PHP:     s.PHP,
+		NodeJS:  s.NodeJS,
+		Nginx:   s.Nginx,
+		Redis:   s.Redis,
+		MongoDB: s.MongoDB,
 	}
 
 	for serv, conf := range services {
@@ -85,16 +172,15 @@ func (s *ServicesConfig) Validate() error {
 			continue
 		}
 
-		errs := conf.Validate()
-		if errs != nil {
-			if e, ok := errs.(*ValidationErrors); ok {
-				errors.Merge(e)
-			} else {
-				errors.Add(errs.Error())
-			}
-		}
+		errors.addFrom(conf.Validate())
 	}
 
+	for _, db := range s.AllDatabases() {
+		errors.addPrefixedFrom(db.ServiceName(), db.Validate())
+	}
+
+	errors.Merge(s.validateDatabaseCollisions())
+
 	if errors.IsEmpty() {
 		return nil
 	}
@@ -112,7 +198,11 @@ func (s *ServicesConfig) IsPresent(service SupportedService) bool {
 	case Nginx:
 		return s.Nginx != nil && !(*s.Nginx == NginxConfig{})
 	case Database:
-		return s.Database != nil && !(*s.Database == DatabaseConfig{})
+		return len(s.AllDatabases()) != 0
+	case Redis:
+		return s.Redis != nil && !(*s.Redis == RedisConfig{})
+	case MongoDB:
+		return s.MongoDB != nil && !(*s.MongoDB == MongoDBConfig{})
 	default:
 		return false
 	}
@@ -143,12 +233,97 @@ func (s *ServicesConfig) presentServices() []SupportedService {
 	return services
 }
 
+// ServiceNames returns names of docker-compose services of the present services
+func (s *ServicesConfig) ServiceNames() []string {
+	var names []string
+
+	for _, sup := range s.presentServices() {
+		if sup != Database {
+			names = append(names, sup.ServiceName())
+			continue
+		}
+
+		for _, db := range s.AllDatabases() {
+			names = append(names, db.ServiceName())
+		}
+	}
+
+	return names
+}
+
+// Dependencies returns names of docker-compose services which present services connect to, keyed by names of the
+// connecting services. Php-fpm connects to databases, Redis and MongoDB, Nginx connects to php-fpm
+func (s *ServicesConfig) Dependencies() map[string][]string {
+	dependencies := map[string][]string{}
+
+	if s.IsPresent(PHP) {
+		var backends []string
+
+		for _, db := range s.AllDatabases() {
+			backends = append(backends, db.ServiceName())
+		}
+
+		for _, backend := range []SupportedService{Redis, MongoDB} {
+			if s.IsPresent(backend) {
+				backends = append(backends, backend.ServiceName())
+			}
+		}
+
+		if len(backends) != 0 {
+			dependencies[PHP.ServiceName()] = backends
+		}
+
+		if s.IsPresent(Nginx) {
+			dependencies[Nginx.ServiceName()] = []string{PHP.ServiceName()}
+		}
+	}
+
+	return dependencies
+}
+
+// validateDatabaseCollisions ensures that databases do not share service names and host ports
+func (s *ServicesConfig) validateDatabaseCollisions() *ValidationErrors {
+	errors := &ValidationErrors{}
+
+	services := map[string]bool{}
+	ports := map[int]string{}
+
+	for _, db := range s.AllDatabases() {
+		name := db.ServiceName()
+
+		if isFixedServiceName(name) {
+			errors.Add(fmt.Sprintf("Database service name %s is reserved", name))
+		}
+
+		if services[name] {
+			errors.Add(fmt.Sprintf("Database service name %s is used more than once", name))
+		}
+
+		services[name] = true
+
+		if db.ExposeOnly {
+			continue
+		}
+
+		if other, ok := ports[db.Port]; ok && db.Port != 0 {
+			errors.Add(fmt.Sprintf("Database port %d is used by both %s and %s", db.Port, other, name))
+		}
+
+		ports[db.Port] = name
+	}
+
+	return errors
+}
+
 func (s *ServicesConfig) String() string {
 	return fmt.Sprintf(
-		"ServicesConfig{PHPConfig: %v, NodeJSConfig: %v, NginxConfig: %v, DatabaseConfig: %v}",
+		"ServicesConfig{PHPConfig: %v, NodeJSConfig: %v, NginxConfig: %v, DatabaseConfig: %v, Databases: %v, RedisConfig: %v, MongoDBConfig: %v}",
 		s.PHP,
 		s.NodeJS,
 		s.Nginx,
 		s.Database,
+		s.Databases,
+		s.Redis,
+		s.MongoDB,
 	)
 }
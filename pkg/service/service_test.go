@@ -1,6 +1,7 @@
 package service_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/Bocmah/phpdocker-gen/pkg/service"
@@ -34,6 +35,14 @@ func dummyConfigWithAllServices() *service.ServicesConfig {
 				RootPassword: "testRoot",
 			},
 		},
+		Redis: &service.RedisConfig{
+			Version: "6.0",
+			Port:    6379,
+		},
+		MongoDB: &service.MongoDBConfig{
+			Version: "4.4",
+			Port:    27017,
+		},
 	}
 }
 
@@ -45,6 +54,8 @@ func TestServicesConfig_IsPresent(t *testing.T) {
 		service.NodeJS:                false,
 		service.Nginx:                 false,
 		service.Database:              false,
+		service.Redis:                 false,
+		service.MongoDB:               false,
 		service.SupportedService(100): false,
 	}
 
@@ -61,6 +72,8 @@ func TestServicesConfig_IsPresent(t *testing.T) {
 		service.NodeJS:                true,
 		service.Nginx:                 true,
 		service.Database:              true,
+		service.Redis:                 true,
+		service.MongoDB:               true,
 		service.SupportedService(100): false,
 	}
 
@@ -78,7 +91,7 @@ func TestServicesConfig_PresentServicesCount(t *testing.T) {
 	}{
 		"all services": {
 			input: dummyConfigWithAllServices(),
-			want:  4,
+			want:  6,
 		},
 		"one service": {
 			input: &service.ServicesConfig{
@@ -126,6 +139,14 @@ func TestSupportedService_String(t *testing.T) {
 			input: service.NodeJS,
 			want:  "NodeJS",
 		},
+		"Redis": {
+			input: service.Redis,
+			want:  "Redis",
+		},
+		"MongoDB": {
+			input: service.MongoDB,
+			want:  "MongoDB",
+		},
 		"unknown": {
 			input: service.SupportedService(100),
 			want:  "Unknown",
@@ -141,3 +162,216 @@ func TestSupportedService_String(t *testing.T) {
 		})
 	}
 }
+
+func TestServicesConfig_FillDefaultsIfNotSet_RedisExtension(t *testing.T) {
+	conf := &service.ServicesConfig{
+		PHP:   &service.PHPConfig{Version: "7.4", Extensions: []string{"mbstring"}},
+		Redis: &service.RedisConfig{},
+	}
+
+	conf.FillDefaultsIfNotSet()
+
+	want := []string{"mbstring", "redis"}
+
+	if !reflect.DeepEqual(conf.PHP.Extensions, want) {
+		t.Errorf("PHP extensions got %v, want %v", conf.PHP.Extensions, want)
+	}
+
+	if conf.Redis.Version != "6.0" || conf.Redis.Port != 6379 {
+		t.Errorf("Redis defaults were not filled: %v", conf.Redis)
+	}
+}
+
+func TestServicesConfig_FillDefaultsIfNotSet_MongoDBExtension(t *testing.T) {
+	conf := &service.ServicesConfig{
+		PHP:     &service.PHPConfig{Version: "7.4", Extensions: []string{"mbstring"}},
+		MongoDB: &service.MongoDBConfig{},
+	}
+
+	conf.FillDefaultsIfNotSet()
+
+	want := []string{"mbstring", "mongodb"}
+
+	if !reflect.DeepEqual(conf.PHP.Extensions, want) {
+		t.Errorf("PHP extensions got %v, want %v", conf.PHP.Extensions, want)
+	}
+
+	if conf.MongoDB.Version != "4.4" || conf.MongoDB.Port != 27017 {
+		t.Errorf("MongoDB defaults were not filled: %v", conf.MongoDB)
+	}
+}
+
+func TestServicesConfig_AllDatabases(t *testing.T) {
+	main := &service.DatabaseConfig{System: service.MySQL}
+	analytics := &service.DatabaseConfig{Service: "analytics", System: service.PostgreSQL}
+
+	tests := map[string]struct {
+		input *service.ServicesConfig
+		want  []*service.DatabaseConfig
+	}{
+		"no databases": {
+			input: &service.ServicesConfig{},
+			want:  nil,
+		},
+		"database key": {
+			input: &service.ServicesConfig{Database: main},
+			want:  []*service.DatabaseConfig{main},
+		},
+		"databases list": {
+			input: &service.ServicesConfig{Databases: []*service.DatabaseConfig{main, analytics}},
+			want:  []*service.DatabaseConfig{main, analytics},
+		},
+		"both keys with empty configs": {
+			input: &service.ServicesConfig{
+				Database:  main,
+				Databases: []*service.DatabaseConfig{nil, {}, analytics},
+			},
+			want: []*service.DatabaseConfig{main, analytics},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := tc.input.AllDatabases()
+
+			if !reflect.DeepEqual(tc.want, got) {
+				t.Fatalf("AllDatabases() got %v, want %v", got, tc.want)
+			}
+
+			if present := tc.input.IsPresent(service.Database); present != (len(tc.want) != 0) {
+				t.Errorf("IsPresent(Database) returned %t for %v", present, tc.input)
+			}
+		})
+	}
+}
+
+func TestServicesConfig_ServiceNames(t *testing.T) {
+	conf := &service.ServicesConfig{
+		PHP:   &service.PHPConfig{Version: "7.4"},
+		Nginx: &service.NginxConfig{ServerName: "app"},
+		Databases: []*service.DatabaseConfig{
+			{System: service.MySQL},
+			{Service: "analytics", System: service.PostgreSQL},
+		},
+		Redis: &service.RedisConfig{Version: "6.0"},
+	}
+
+	want := []string{"php-fpm", "webserver", "db", "analytics", "redis"}
+
+	if got := conf.ServiceNames(); !reflect.DeepEqual(want, got) {
+		t.Errorf("ServiceNames() got %v, want %v", got, want)
+	}
+}
+
+func TestServicesConfig_Dependencies(t *testing.T) {
+	conf := &service.ServicesConfig{
+		PHP:    &service.PHPConfig{Version: "7.4"},
+		Nginx:  &service.NginxConfig{ServerName: "app"},
+		NodeJS: &service.NodeJSConfig{Version: "14"},
+		Databases: []*service.DatabaseConfig{
+			{System: service.MySQL},
+			{Service: "analytics", System: service.PostgreSQL},
+		},
+		Redis: &service.RedisConfig{Version: "6.0"},
+	}
+
+	want := map[string][]string{
+		"php-fpm":   {"db", "analytics", "redis"},
+		"webserver": {"php-fpm"},
+	}
+
+	if got := conf.Dependencies(); !reflect.DeepEqual(want, got) {
+		t.Errorf("Dependencies() got %v, want %v", got, want)
+	}
+}
+
+func TestServicesConfig_FillDefaultsIfNotSet_Databases(t *testing.T) {
+	conf := &service.ServicesConfig{
+		PHP: &service.PHPConfig{Version: "7.4", Extensions: []string{"mbstring"}},
+		Databases: []*service.DatabaseConfig{
+			{System: service.MySQL},
+			{Service: "analytics", System: service.PostgreSQL},
+		},
+	}
+
+	conf.FillDefaultsIfNotSet()
+
+	want := []string{"mbstring", "pdo_mysql", "pdo_pgsql"}
+
+	if !reflect.DeepEqual(conf.PHP.Extensions, want) {
+		t.Errorf("PHP extensions got %v, want %v", conf.PHP.Extensions, want)
+	}
+
+	if conf.Databases[1].Port != 5432 || conf.Databases[1].Version != "12.3" {
+		t.Errorf("Database defaults were not filled: %v", conf.Databases[1])
+	}
+}
+
+func TestServicesConfig_ValidateDatabaseCollisions(t *testing.T) {
+	db := func(name string, port int) *service.DatabaseConfig {
+		return &service.DatabaseConfig{
+			Service:     name,
+			System:      service.MySQL,
+			Port:        port,
+			Credentials: service.Credentials{RootPassword: "root"},
+		}
+	}
+
+	tests := map[string]struct {
+		input    *service.ServicesConfig
+		wantErrs []string
+	}{
+		"duplicate default service name": {
+			input: &service.ServicesConfig{
+				Database:  db("", 3306),
+				Databases: []*service.DatabaseConfig{db("", 3307)},
+			},
+			wantErrs: []string{"Database service name db is used more than once"},
+		},
+		"duplicate port": {
+			input: &service.ServicesConfig{
+				Databases: []*service.DatabaseConfig{db("main", 3306), db("legacy", 3306)},
+			},
+			wantErrs: []string{"Database port 3306 is used by both main and legacy"},
+		},
+		"invalid database is named": {
+			input: &service.ServicesConfig{
+				Databases: []*service.DatabaseConfig{db("main", 3306), db("legacy", 0)},
+			},
+			wantErrs: []string{"legacy: DatabaseConfig port is required"},
+		},
+		"reserved service name": {
+			input: &service.ServicesConfig{
+				Databases: []*service.DatabaseConfig{db("redis", 3306)},
+			},
+			wantErrs: []string{"Database service name redis is reserved"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs := tc.input.Validate()
+
+			if errs != nil {
+				res := validationResult{
+					wantErrs:     tc.wantErrs,
+					actualErrs:   errs,
+					validatedVal: tc.input,
+				}
+
+				failTestOnUnspottedError(res, t)
+			} else {
+				t.Errorf("Did not return any errors for value %v", tc.input)
+			}
+		})
+	}
+
+	exposed := db("internal", 3306)
+	exposed.ExposeOnly = true
+
+	correct := &service.ServicesConfig{
+		Databases: []*service.DatabaseConfig{db("main", 3306), db("legacy", 3307), exposed},
+	}
+
+	failTestOnErrorsOnCorrectInput(correct.Validate(), t)
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+
+	return false
+}
+
+// maskSecret hides secret in the string representation of the config. Empty secret is kept, so it is clear that it is
+// not set
+func maskSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+
+	return "***"
+}
+
+// isInside determines whether path is root itself or is located inside of it. Both paths must be clean
+func isInside(root string, path string) bool {
+	rel, err := filepath.Rel(root, path)
+
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -1,6 +1,9 @@
 package service
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 // ValidationErrors is a collection of validation errors
 type ValidationErrors []string
@@ -34,3 +37,27 @@ func (v ValidationErrors) Has(err string) bool {
 func (v *ValidationErrors) Merge(errs *ValidationErrors) {
 	v.Add(*errs...)
 }
+
+// addFrom adds errors returned by Config.Validate to the collection
+func (v *ValidationErrors) addFrom(err error) {
+	if err == nil {
+		return
+	}
+
+	if e, ok := err.(*ValidationErrors); ok {
+		v.Merge(e)
+	} else {
+		v.Add(err.Error())
+	}
+}
+
+// addPrefixedFrom adds errors returned by Config.Validate to the collection. Each of them is prefixed with the name
+// of the validated entity, so configs of the same type can be told apart
+func (v *ValidationErrors) addPrefixedFrom(prefix string, err error) {
+	errs := &ValidationErrors{}
+	errs.addFrom(err)
+
+	for _, e := range *errs {
+		v.Add(fmt.Sprintf("%s: %s", prefix, e))
+	}
+}
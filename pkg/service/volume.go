@@ -0,0 +1,243 @@
+package service
+
+import (
+	"fmt"
+	"path"
+	"sort"
+)
+
+// VolumeConfig is a user-defined named volume. It is created in addition to the volumes which keep data of services
+type VolumeConfig struct {
+	Name       string
+	Driver     string            `yaml:",omitempty"`
+	DriverOpts map[string]string `yaml:"driverOpts,omitempty"`
+	Labels     map[string]string `yaml:",omitempty"`
+	// External volume is created outside of the project (e.g. docker volume create)
+	External bool `yaml:",omitempty"`
+	// Services are keyed by docker-compose service name (e.g. php-fpm or nodejs)
+	Services map[string]*VolumeMountConfig `yaml:",omitempty"`
+}
+
+// VolumeMountConfig configures where the volume is mounted inside the container of the service
+type VolumeMountConfig struct {
+	Target   string
+	ReadOnly bool `yaml:"readOnly,omitempty"`
+}
+
+// FillDefaultsIfNotSet fills default volume parameters if they are not present
+func (v *VolumeConfig) FillDefaultsIfNotSet() {
+	if v.Driver == "" && !v.External {
+		v.Driver = "local"
+	}
+}
+
+// Validate validates volume parameters
+func (v *VolumeConfig) Validate() error {
+	errors := &ValidationErrors{}
+
+	if v.Name == "" {
+		errors.Add("Volume name is required")
+	}
+
+	if v.External && (v.Driver != "" || len(v.DriverOpts) != 0 || len(v.Labels) != 0) {
+		errors.Add(fmt.Sprintf("External volume %s can not have driver, driver options or labels", v.Name))
+	}
+
+	for _, name := range v.serviceNames() {
+		mount := v.Services[name]
+
+		switch {
+		case mount == nil || mount.Target == "":
+			errors.Add(fmt.Sprintf("Volume %s target of %s is required", v.Name, name))
+		case !path.IsAbs(mount.Target):
+			errors.Add(fmt.Sprintf("Volume %s target %s of %s must be an absolute path", v.Name, mount.Target, name))
+		}
+	}
+
+	if errors.IsEmpty() {
+		return nil
+	}
+
+	return errors
+}
+
+// serviceNames returns names of the services the volume is mounted to in alphabetical order
+func (v *VolumeConfig) serviceNames() []string {
+	names := make([]string, 0, len(v.Services))
+
+	for name := range v.Services {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func (v *VolumeConfig) String() string {
+	return fmt.Sprintf(
+		"VolumeConfig{Name: %s, Driver: %s, DriverOpts: %v, Labels: %v, External: %t, Services: %v}",
+		v.Name,
+		v.Driver,
+		v.DriverOpts,
+		v.Labels,
+		v.External,
+		v.Services,
+	)
+}
+
+// DataVolume is a generated named volume which keeps data of the service across container lifecycles
+type DataVolume struct {
+	Name string
+	// Service is the name of docker-compose service the volume is mounted to
+	Service string
+	Target  string
+}
+
+// DataVolumes returns volumes which keep data of databases, Redis and MongoDB. Data volume of the default database
+// service keeps the name it had before multiple databases were supported, so existing data is not lost
+func (c *FullConfig) DataVolumes() []*DataVolume {
+	var volumes []*DataVolume
+
+	appName := c.FormattedAppName()
+
+	for _, db := range c.Services.AllDatabases() {
+		name := fmt.Sprintf("%s-%s-data", appName, db.ServiceName())
+
+		if db.ServiceName() == DefaultDatabaseService {
+			name = fmt.Sprintf("%s-data", appName)
+		}
+
+		volumes = append(volumes, &DataVolume{Name: name, Service: db.ServiceName(), Target: db.System.DataPath()})
+	}
+
+	if c.Services.IsPresent(Redis) && c.Services.Redis.Persistence {
+		volumes = append(volumes, &DataVolume{
+			Name:    fmt.Sprintf("%s-redis-data", appName),
+			Service: Redis.ServiceName(),
+			Target:  RedisDataPath,
+		})
+	}
+
+	if c.Services.IsPresent(MongoDB) {
+		volumes = append(volumes, &DataVolume{
+			Name:    fmt.Sprintf("%s-mongodb-data", appName),
+			Service: MongoDB.ServiceName(),
+			Target:  MongoDBDataPath,
+		})
+	}
+
+	return volumes
+}
+
+// mountTargets returns paths inside containers to which project root, generated files and data volumes are mounted
+// keyed by docker-compose service name. Values describe what is mounted
+func (c *FullConfig) mountTargets() map[string]map[string]string {
+	mounts := map[string]map[string]string{}
+
+	add := func(service string, target string, source string) {
+		if mounts[service] == nil {
+			mounts[service] = map[string]string{}
+		}
+
+		mounts[service][path.Clean(target)] = source
+	}
+
+	files := c.GetServiceFiles()
+
+	for _, serv := range c.Services.presentServices() {
+		if serv == Database {
+			continue
+		}
+
+		if target := serv.ProjectRootPath(); target != "" {
+			add(serv.ServiceName(), target, "project root")
+		}
+
+		for _, file := range files[serv] {
+			if file.IsMountable() {
+				add(serv.ServiceName(), file.PathInContainer, "file "+file.PathOnHost)
+			}
+		}
+	}
+
+	for _, db := range c.Services.AllDatabases() {
+		if file := db.ConfigFile(c.GetOutputPath()); file != nil && file.IsMountable() {
+			add(db.ServiceName(), file.PathInContainer, "file "+file.PathOnHost)
+		}
+
+		targets := db.InitScriptsInContainer()
+
+		for i, script := range db.InitScriptsOnHost(c.ProjectRoot) {
+			add(db.ServiceName(), targets[i], "file "+script)
+		}
+	}
+
+	for _, volume := range c.DataVolumes() {
+		add(volume.Service, volume.Target, "volume "+volume.Name)
+	}
+
+	return mounts
+}
+
+// validateVolumes ensures that volumes have unique names which are not used by data volumes, are mounted to services
+// present in the config and do not share targets with other mounts of the service
+func (c *FullConfig) validateVolumes() *ValidationErrors {
+	errors := &ValidationErrors{}
+	services := c.Services.ServiceNames()
+	mounts := c.mountTargets()
+	declared := map[string]bool{}
+	reserved := map[string]string{}
+
+	for _, volume := range c.DataVolumes() {
+		reserved[volume.Name] = volume.Service
+	}
+
+	for _, volume := range c.Volumes {
+		errors.addFrom(volume.Validate())
+
+		if service, ok := reserved[volume.Name]; ok {
+			errors.Add(fmt.Sprintf("Volume name %s is reserved for data of %s", volume.Name, service))
+		}
+
+		if declared[volume.Name] && volume.Name != "" {
+			errors.Add(fmt.Sprintf("Volume %s is declared more than once", volume.Name))
+		}
+
+		declared[volume.Name] = true
+
+		for _, name := range volume.serviceNames() {
+			if !contains(services, name) {
+				errors.Add(fmt.Sprintf("Volume %s has unknown service %s", volume.Name, name))
+				continue
+			}
+
+			mount := volume.Services[name]
+
+			if mount == nil || mount.Target == "" {
+				continue
+			}
+
+			target := path.Clean(mount.Target)
+
+			if source, ok := mounts[name][target]; ok {
+				errors.Add(fmt.Sprintf(
+					"Volume %s target %s of %s is already used by %s",
+					volume.Name,
+					mount.Target,
+					name,
+					source,
+				))
+				continue
+			}
+
+			if mounts[name] == nil {
+				mounts[name] = map[string]string{}
+			}
+
+			mounts[name][target] = "volume " + volume.Name
+		}
+	}
+
+	return errors
+}
@@ -0,0 +1,133 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/Bocmah/phpdocker-gen/pkg/service"
+)
+
+func TestVolume_FillDefaultsIfNotSet(t *testing.T) {
+	tests := map[string]struct {
+		conf service.VolumeConfig
+		want service.VolumeConfig
+	}{
+		"empty": {
+			conf: service.VolumeConfig{Name: "composer-cache"},
+			want: service.VolumeConfig{Name: "composer-cache", Driver: "local"},
+		},
+		"external": {
+			conf: service.VolumeConfig{Name: "shared-data", External: true},
+			want: service.VolumeConfig{Name: "shared-data", External: true},
+		},
+		"driver is set": {
+			conf: service.VolumeConfig{Name: "composer-cache", Driver: "nfs"},
+			want: service.VolumeConfig{Name: "composer-cache", Driver: "nfs"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.conf.FillDefaultsIfNotSet()
+
+			if diff := cmp.Diff(tc.want, tc.conf); diff != "" {
+				t.Errorf("FillDefaultsIfNotSet() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestVolume_ValidateIncorrectInput(t *testing.T) {
+	tests := map[string]struct {
+		conf     service.VolumeConfig
+		wantErrs []string
+	}{
+		"empty": {
+			conf:     service.VolumeConfig{},
+			wantErrs: []string{"Volume name is required"},
+		},
+		"external with options": {
+			conf: service.VolumeConfig{
+				Name:       "shared-data",
+				External:   true,
+				DriverOpts: map[string]string{"type": "tmpfs"},
+			},
+			wantErrs: []string{"External volume shared-data can not have driver, driver options or labels"},
+		},
+		"invalid targets": {
+			conf: service.VolumeConfig{
+				Name:   "node-modules",
+				Driver: "local",
+				Services: map[string]*service.VolumeMountConfig{
+					"nodejs":  {Target: "node_modules"},
+					"php-fpm": nil,
+					"redis":   {ReadOnly: true},
+				},
+			},
+			wantErrs: []string{
+				"Volume node-modules target node_modules of nodejs must be an absolute path",
+				"Volume node-modules target of php-fpm is required",
+				"Volume node-modules target of redis is required",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs := tc.conf.Validate()
+
+			if errs != nil {
+				res := validationResult{
+					wantErrs:     tc.wantErrs,
+					actualErrs:   errs,
+					validatedVal: tc.conf,
+				}
+
+				failTestOnUnspottedError(res, t)
+			} else {
+				t.Errorf("Did not return any errors for value %v", tc.conf)
+			}
+		})
+	}
+}
+
+func TestVolume_ValidateCorrectInput(t *testing.T) {
+	volume := service.VolumeConfig{
+		Name:       "node-modules",
+		Driver:     "local",
+		DriverOpts: map[string]string{"type": "tmpfs", "device": "tmpfs"},
+		Labels:     map[string]string{"com.example.app": "awesome-app"},
+		Services: map[string]*service.VolumeMountConfig{
+			"nodejs":  {Target: "/var/www/node_modules"},
+			"php-fpm": {Target: "/var/www/node_modules", ReadOnly: true},
+		},
+	}
+
+	failTestOnErrorsOnCorrectInput(volume.Validate(), t)
+}
+
+func TestFullConfig_DataVolumes(t *testing.T) {
+	conf := &service.FullConfig{
+		AppName: "Test App",
+		Services: &service.ServicesConfig{
+			Database: &service.DatabaseConfig{System: service.MySQL},
+			Databases: []*service.DatabaseConfig{
+				{Service: "analytics", System: service.PostgreSQL},
+			},
+			Redis:   &service.RedisConfig{Version: "6", Persistence: true},
+			MongoDB: &service.MongoDBConfig{Version: "4.4"},
+		},
+	}
+
+	want := []*service.DataVolume{
+		{Name: "test-app-data", Service: "db", Target: "/var/lib/mysql"},
+		{Name: "test-app-analytics-data", Service: "analytics", Target: "/var/lib/postgresql/data"},
+		{Name: "test-app-redis-data", Service: "redis", Target: "/data"},
+		{Name: "test-app-mongodb-data", Service: "mongodb", Target: "/data/db"},
+	}
+
+	if diff := cmp.Diff(want, conf.DataVolumes()); diff != "" {
+		t.Errorf("DataVolumes() mismatch (-want +got):\n%s", diff)
+	}
+}